@@ -0,0 +1,131 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+)
+
+import flag "github.com/spf13/pflag"
+
+// runDiff implements "html2csv diff", which compares the selected tables of
+// two documents by a key column. Tables are paired by position.
+func runDiff(args []string) {
+	var opts struct {
+		delim  string
+		format string
+		key    string
+		output string
+		tables string
+		tsv    bool
+	}
+
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [OPTIONS] OLD NEW\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.StringVarP(&opts.delim, "delimiter", "d", ",", "delimiter")
+	fs.StringVarP(&opts.format, "format", "f", "csv", "output format: csv, json")
+	fs.StringVarP(&opts.key, "key", "k", "", "key column (required)")
+	fs.StringVarP(&opts.output, "output", "o", "", "write output to file")
+	fs.StringVarP(&opts.tables, "table", "t", "", "select tables by index or name")
+	fs.BoolVarP(&opts.tsv, "tsv", "T", false, "use TAB as delimiter")
+	var popts parserOptions
+	popts.register(fs)
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 || opts.key == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	r := []rune(opts.delim)
+	if len(r) != 1 {
+		fmt.Fprintf(os.Stderr, "delimiter must be a single character\n")
+		os.Exit(1)
+	}
+	delimiter := r[0]
+	if opts.tsv {
+		delimiter = '\t'
+	}
+
+	parser, err := popts.parser()
+	if err != nil {
+		log.Fatal(err)
+	}
+	sel, err := htmltable.ParseSelector(opts.tables)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	before := readTables(parser, sel, fs.Arg(0))
+	after := readTables(parser, sel, fs.Arg(1))
+	if len(before) != len(after) {
+		log.Fatalf("table count differs: %d in %s, %d in %s", len(before), fs.Arg(0), len(after), fs.Arg(1))
+	}
+
+	diffs := make([]htmltable.TableDiff, len(before))
+	for i := range before {
+		diffs[i], err = htmltable.Diff(before[i], after[i], opts.key)
+		if err != nil {
+			log.Fatalf("table %d: %v", after[i].Index, err)
+		}
+	}
+
+	out := os.Stdout
+	if opts.output != "" {
+		out, err = os.Create(opts.output)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	switch opts.format {
+	case "csv":
+		tables := make([]htmltable.Table, len(diffs))
+		for i, d := range diffs {
+			tables[i] = d.Table()
+		}
+		enc := htmltable.NewCSVEncoder()
+		enc.Comma = delimiter
+		err = enc.Encode(out, tables)
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(diffs)
+	default:
+		log.Fatalf("unknown format %q", opts.format)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readTables parses the file or URL at path, or standard input if path
+// is "-", and returns the selected tables.
+func readTables(parser *htmltable.Parser, sel htmltable.Selector, path string) []htmltable.Table {
+	src := path
+	if src == "-" {
+		src = ""
+	}
+	in, err := openInput(src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer in.Close()
+
+	tables, err := parser.ParseArchive(in)
+	if err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+	return sel.Apply(tables)
+}
@@ -0,0 +1,59 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+)
+
+const sampleRows = 3
+
+// listTables writes a summary of every table, as JSON if asJSON is set.
+func listTables(w io.Writer, tables []htmltable.Table, asJSON bool) error {
+	summaries := make([]htmltable.Summary, len(tables))
+	for i, t := range tables {
+		summaries[i] = t.Summary(sampleRows)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summaries)
+	}
+
+	var b strings.Builder
+	for i, s := range summaries {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Table %d", s.Index)
+		if s.ID != "" {
+			fmt.Fprintf(&b, " id=%q", s.ID)
+		}
+		if s.Name != "" {
+			fmt.Fprintf(&b, " name=%q", s.Name)
+		}
+		fmt.Fprintf(&b, ": %d rows x %d columns", s.Rows, s.Columns)
+		if s.RowHeaders {
+			b.WriteString(", row headers")
+		}
+		b.WriteString("\n")
+		if s.Caption != "" {
+			fmt.Fprintf(&b, "  Caption: %s\n", s.Caption)
+		}
+		if s.Section != "" {
+			fmt.Fprintf(&b, "  Section: %s\n", s.Section)
+		}
+		fmt.Fprintf(&b, "  Header:  %s\n", strings.Join(s.Header, " | "))
+		for _, row := range s.Sample {
+			fmt.Fprintf(&b, "           %s\n", strings.Join(row, " | "))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -7,8 +7,11 @@ import (
 	"log"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/ricardobranco777/html2csv/htmltable"
+	"github.com/ricardobranco777/html2csv/htmltable/sqlite"
 )
 
 import flag "github.com/spf13/pflag"
@@ -16,23 +19,87 @@ import flag "github.com/spf13/pflag"
 const Version = "0.7.0"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		log.SetFlags(0)
+		log.SetPrefix("ERROR: ")
+		runDiff(os.Args[2:])
+		return
+	}
+
 	var opts struct {
+		border     string
+		columns    string
 		delim      string
+		format     string
+		output     string
+		separator  string
+		source     string
+		splitBy    string
+		dialect    string
+		hook       string
+		null       string
+		quote      string
+		next       string
+		state      string
 		tables     string
+		transpose  string
+		maxPages   int
+		maxWidth   int
+		appendDB   bool
+		bom        bool
+		crlf       bool
+		quoteAll   bool
+		follow     bool
+		infer      bool
+		interact   bool
+		list       bool
+		merge      bool
 		skipHeader bool
+		sortable   bool
 		tsv        bool
 		version    bool
+		watch      time.Duration
 	}
+	var popts parserOptions
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS] FILE\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS] FILE|URL\n       %s diff [OPTIONS] OLD NEW\n", os.Args[0], os.Args[0])
 		flag.PrintDefaults()
 	}
+	flag.BoolVarP(&opts.appendDB, "append", "", false, "append to existing SQLite tables")
+	flag.StringVarP(&opts.border, "border", "", "box", "text borders: box, ascii, none")
+	flag.BoolVarP(&opts.bom, "bom", "", false, "write a UTF-8 byte order mark")
+	flag.StringVarP(&opts.columns, "columns", "c", "", "select columns by index or header name")
+	flag.BoolVarP(&opts.crlf, "crlf", "", false, "end lines with CRLF")
 	flag.StringVarP(&opts.delim, "delimiter", "d", ",", "delimiter")
+	flag.StringVarP(&opts.hook, "exec", "", "", "run command with the output on stdin instead of writing it to stdout, when it changes")
+	flag.BoolVarP(&opts.follow, "follow", "", false, "follow rel=\"next\" links and concatenate the tables of every page")
+	flag.StringVarP(&opts.format, "format", "f", "csv", "output format: "+strings.Join(htmltable.Formats(), ", "))
+	flag.BoolVarP(&opts.infer, "infer", "", false, "infer column types for JSON and SQL output (parquet and sqlite always do)")
+	flag.BoolVarP(&opts.interact, "interactive", "i", false, "pick tables and columns interactively")
+	flag.BoolVarP(&opts.list, "list", "l", false, "list tables with their dimensions, header and sample rows")
+	flag.IntVarP(&opts.maxPages, "max-pages", "", 10, "maximum number of pages to follow (0 for no limit)")
+	flag.IntVarP(&opts.maxWidth, "max-width", "", 0, "truncate text columns to this width (0 for no limit)")
+	flag.BoolVarP(&opts.merge, "merge", "m", false, "merge tables into one by header name")
+	flag.StringVarP(&opts.next, "next", "", "", "follow links matching this selector (e.g. \"li.next a\")")
+	flag.StringVarP(&opts.null, "null", "", "", "write empty cells as this string")
 	flag.BoolVarP(&opts.skipHeader, "no-header", "H", false, "skip table header")
+	flag.StringVarP(&opts.quote, "quote", "", "\"", "quote character")
+	flag.BoolVarP(&opts.quoteAll, "quote-all", "", false, "quote every field")
+	flag.StringVarP(&opts.output, "output", "o", "", "write output to file")
+	flag.StringVarP(&opts.separator, "separator", "", "blank", "between tables write: none, blank, comment or a custom line")
+	flag.StringVarP(&opts.state, "state", "", "", "only write output if tables changed since the hash in state file")
+	flag.StringVarP(&opts.source, "source-column", "", "", "with --merge, add a column with this name holding the source table")
+	flag.BoolVarP(&opts.sortable, "sortable", "", false, "make HTML tables sortable")
+	flag.StringVarP(&opts.splitBy, "split-by", "", "", "split tables by the value of this column")
+	flag.StringVarP(&opts.dialect, "sql-dialect", "", "postgres", "SQL dialect: postgres, mysql, sqlite")
 	flag.StringVarP(&opts.tables, "table", "t", "", "select tables by index or name")
+	flag.StringVarP(&opts.transpose, "transpose", "", "", "transpose tables with row headers (auto) or all tables (always)")
+	flag.Lookup("transpose").NoOptDefVal = "auto"
 	flag.BoolVarP(&opts.tsv, "tsv", "T", false, "use TAB as delimiter")
 	flag.BoolVarP(&opts.version, "version", "", false, "print version and exit")
+	flag.DurationVarP(&opts.watch, "watch", "", 0, "re-read input on this interval and write output on changes (e.g. 5m)")
+	popts.register(flag.CommandLine)
 	flag.Parse()
 
 	if opts.version {
@@ -43,19 +110,28 @@ func main() {
 	log.SetFlags(0)
 	log.SetPrefix("ERROR: ")
 
-	f := os.Stdin
-	var err error
-
 	if flag.NArg() > 1 {
 		flag.Usage()
 		os.Exit(1)
 	}
-	if flag.NArg() == 1 {
-		f, err = os.Open(flag.Arg(0))
-		if err != nil {
-			log.Fatal(err)
-		}
-		defer f.Close()
+	if opts.watch > 0 && flag.NArg() == 0 {
+		log.Fatal("--watch requires a file or URL")
+	}
+	if opts.interact && (opts.watch > 0 || opts.list) {
+		log.Fatal("--interactive cannot be used with --watch or --list")
+	}
+
+	if opts.next != "" {
+		opts.follow = true
+	}
+	if opts.follow && !strings.HasPrefix(flag.Arg(0), "http://") && !strings.HasPrefix(flag.Arg(0), "https://") {
+		log.Fatal("--follow and --next require a URL")
+	}
+	if opts.source != "" && !opts.merge {
+		log.Fatal("--source-column requires --merge")
+	}
+	if opts.transpose != "" && opts.transpose != "auto" && opts.transpose != "always" {
+		log.Fatalf("invalid --transpose value %q", opts.transpose)
 	}
 
 	r := []rune(opts.delim)
@@ -68,25 +144,161 @@ func main() {
 		delimiter = '\t'
 	}
 
-	tables, err := htmltable.Parse(f)
+	q := []rune(opts.quote)
+	if len(q) != 1 {
+		fmt.Fprintf(os.Stderr, "quote must be a single character\n")
+		os.Exit(1)
+	}
+
+	parser, err := popts.parser()
 	if err != nil {
 		log.Fatal(err)
 	}
-
 	sel, err := htmltable.ParseSelector(opts.tables)
 	if err != nil {
 		log.Fatal(err)
 	}
-	tables = sel.Apply(tables)
+	cols, err := htmltable.ParseColumnSelector(opts.columns)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	if opts.skipHeader {
-		tables = htmltable.SkipHeader(tables)
+	var pager *htmltable.Paginator
+	if opts.follow {
+		next, err := htmltable.ParseLinkSelector(opts.next)
+		if err != nil {
+			log.Fatal(err)
+		}
+		pager = htmltable.NewPaginator(parser)
+		pager.Client = httpClient
+		pager.Next = next
+		pager.MaxPages = opts.maxPages
 	}
 
-	enc := htmltable.NewCSVEncoder()
-	enc.Comma = delimiter
+	j := &job{
+		src:        flag.Arg(0),
+		parser:     parser,
+		pager:      pager,
+		sel:        sel,
+		cols:       cols,
+		transpose:  opts.transpose,
+		merge:      opts.merge,
+		source:     opts.source,
+		splitBy:    opts.splitBy,
+		skipHeader: opts.skipHeader,
+		output:     opts.output,
+		state:      opts.state,
+		hook:       opts.hook,
+		watching:   opts.watch > 0,
+	}
+
+	if opts.list {
+		tables, err := j.load()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := listTables(os.Stdout, cols.Apply(sel.Apply(tables)), opts.format == "json"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	if err := enc.Encode(os.Stdout, tables); err != nil {
+	enc, err := htmltable.NewEncoder(opts.format)
+	if err != nil {
 		log.Fatal(err)
 	}
+	switch enc := enc.(type) {
+	case *htmltable.CSVEncoder:
+		enc.Comma = delimiter
+		enc.Quote = q[0]
+		enc.QuoteAll = opts.quoteAll
+		enc.UseCRLF = opts.crlf
+		enc.BOM = opts.bom
+		enc.Null = opts.null
+		switch opts.separator {
+		case "blank":
+			enc.Separator = htmltable.BlankSeparator
+		case "none":
+			enc.Separator = htmltable.NoSeparator
+		case "comment":
+			enc.Separator = htmltable.CommentSeparator
+		default:
+			enc.Separator = htmltable.CustomSeparator
+			enc.SeparatorText = opts.separator
+		}
+	case *htmltable.HTMLEncoder:
+		if flag.NArg() == 1 {
+			enc.Title = flag.Arg(0)
+		}
+		enc.Header = !opts.skipHeader
+		enc.Sortable = opts.sortable
+	case *htmltable.JSONEncoder:
+		enc.Infer = opts.infer
+	case *htmltable.SQLEncoder:
+		enc.Dialect = opts.dialect
+		enc.Infer = opts.infer
+	case *sqlite.Encoder:
+		enc.Path = opts.output
+		enc.Append = opts.appendDB
+	case *htmltable.TextEncoder:
+		switch opts.border {
+		case "box":
+			enc.Style = htmltable.BoxStyle
+		case "ascii":
+			enc.Style = htmltable.ASCIIStyle
+		case "none":
+			enc.Style = htmltable.PlainStyle
+		default:
+			log.Fatalf("unknown border style %q", opts.border)
+		}
+		enc.MaxWidth = opts.maxWidth
+		enc.Header = !opts.skipHeader
+	}
+
+	j.enc = enc
+
+	if opts.interact {
+		tables, err := j.load()
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Columns are picked on the tables as transformed by emit
+		shown, err := j.transform(tables)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tableSel, colSel, err := browse(shown)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if j.merge {
+			tableSel = opts.tables
+		}
+		if colSel == "" {
+			colSel = opts.columns
+		}
+		fmt.Fprintln(os.Stderr, commandLine(tableSel, colSel))
+
+		// Both selections were validated by the browser
+		j.sel, _ = htmltable.ParseSelector(tableSel)
+		j.cols, _ = htmltable.ParseColumnSelector(colSel)
+		if err := j.emit(tables); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if opts.watch <= 0 {
+		if err := j.run(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	for {
+		if err := j.run(); err != nil {
+			log.Print(err)
+		}
+		time.Sleep(opts.watch)
+	}
 }
@@ -0,0 +1,51 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package main
+
+import (
+	"github.com/ricardobranco777/html2csv/htmltable"
+)
+
+import flag "github.com/spf13/pflag"
+
+// parserOptions holds the flags that control how tables are extracted,
+// shared by the main command and diff.
+type parserOptions struct {
+	attrs      string
+	sections   string
+	keepCols   bool
+	keepRows   bool
+	noListing  bool
+	noTrim     bool
+	skipHidden bool
+}
+
+func (o *parserOptions) register(fs *flag.FlagSet) {
+	fs.StringVarP(&o.attrs, "attr", "a", "", "take cell values from attributes (e.g. \"title,img@alt,text\")")
+	fs.BoolVarP(&o.keepCols, "keep-empty-columns", "", false, "keep empty columns")
+	fs.BoolVarP(&o.keepRows, "keep-empty-rows", "", false, "keep empty rows")
+	fs.BoolVarP(&o.noListing, "no-listing", "", false, "do not parse directory listings")
+	fs.BoolVarP(&o.noTrim, "no-trim", "", false, "keep leading and trailing whitespace")
+	fs.StringVarP(&o.sections, "sections", "", "none", "handle full-width section rows: none, column, split")
+	fs.BoolVarP(&o.skipHidden, "skip-hidden", "", false, "skip hidden rows")
+}
+
+func (o *parserOptions) parser() (*htmltable.Parser, error) {
+	values, err := htmltable.ParseValueRules(o.attrs)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := htmltable.ParseSectionMode(o.sections)
+	if err != nil {
+		return nil, err
+	}
+	return htmltable.NewParser(
+		htmltable.WithValueRules(values...),
+		htmltable.WithEmptyColumns(o.keepCols),
+		htmltable.WithEmptyRows(o.keepRows),
+		htmltable.WithHiddenRows(!o.skipHidden),
+		htmltable.WithTrimSpace(!o.noTrim),
+		htmltable.WithDirectoryListing(!o.noListing),
+		htmltable.WithSections(sections),
+	), nil
+}
@@ -0,0 +1,404 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+	"golang.org/x/term"
+)
+
+import flag "github.com/spf13/pflag"
+
+const maxCellWidth = 24
+
+var errCanceled = errors.New("canceled")
+
+// browser is an interactive terminal table picker.
+// Keys are read from and the screen is drawn on the controlling terminal,
+// so that the document may be read from standard input.
+type browser struct {
+	tty    *os.File
+	tables []htmltable.Table
+	picked map[int]bool // positions in tables
+	cols   map[int]bool // 1-based column indexes
+
+	cur     int // current table
+	listTop int
+
+	viewing         bool
+	row, col        int // cursor in the current table
+	rowTop, colLeft int
+
+	width, height int
+}
+
+// browse lets the user pick tables and columns and returns the equivalent
+// --table and --columns values.
+func browse(tables []htmltable.Table) (tableSel, colSel string, err error) {
+	if len(tables) == 0 {
+		return "", "", errors.New("no tables found")
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", "", err
+	}
+	defer tty.Close()
+
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return "", "", err
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	// Use the alternate screen and hide the cursor
+	fmt.Fprint(tty, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(tty, "\x1b[?25h\x1b[?1049l")
+
+	b := &browser{
+		tty:    tty,
+		tables: tables,
+		picked: make(map[int]bool),
+		cols:   make(map[int]bool),
+	}
+	if err := b.loop(); err != nil {
+		return "", "", err
+	}
+
+	// Parts of a split table share its index
+	var ts, cs []string
+	for i, t := range tables {
+		index := strconv.Itoa(t.Index)
+		if (b.picked[i] || len(b.picked) == 0 && i == b.cur) && !slices.Contains(ts, index) {
+			ts = append(ts, index)
+		}
+	}
+	for _, c := range slices.Sorted(mapKeys(b.cols)) {
+		cs = append(cs, strconv.Itoa(c))
+	}
+	return strings.Join(ts, ","), strings.Join(cs, ","), nil
+}
+
+func mapKeys(m map[int]bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for k, v := range m {
+			if v && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (b *browser) loop() error {
+	buf := make([]byte, 16)
+	for {
+		b.width, b.height = 80, 24
+		if w, h, err := term.GetSize(int(b.tty.Fd())); err == nil && w > 0 && h > 0 {
+			b.width, b.height = w, h
+		}
+		b.draw()
+
+		n, err := b.tty.Read(buf)
+		if err != nil {
+			return err
+		}
+		done, err := b.key(string(buf[:n]))
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// key handles a key press and reports whether browsing is finished.
+func (b *browser) key(k string) (bool, error) {
+	t := b.tables[b.cur]
+	page := max(1, b.height-4)
+
+	switch k {
+	case "q":
+		return true, nil
+	case "\x03": // Ctrl-C
+		return true, errCanceled
+	case "t":
+		b.picked[b.cur] = !b.picked[b.cur]
+		return false, nil
+	}
+
+	if !b.viewing {
+		switch k {
+		case "\x1b":
+			return true, errCanceled
+		case "\x1b[A", "k":
+			b.cur = max(0, b.cur-1)
+		case "\x1b[B", "j":
+			b.cur = min(len(b.tables)-1, b.cur+1)
+		case "\x1b[5~":
+			b.cur = max(0, b.cur-page)
+		case "\x1b[6~":
+			b.cur = min(len(b.tables)-1, b.cur+page)
+		case " ":
+			b.picked[b.cur] = !b.picked[b.cur]
+		case "\r", "\n", "\x1b[C", "l":
+			b.viewing = true
+			b.row, b.col, b.rowTop, b.colLeft = 0, 0, 0, 0
+		}
+		return false, nil
+	}
+
+	switch k {
+	case "\x1b", "\r", "\n", "\x7f":
+		b.viewing = false
+	case "\x1b[A", "k":
+		b.row = max(0, b.row-1)
+	case "\x1b[B", "j":
+		b.row = min(len(t.Rows)-1, b.row+1)
+	case "\x1b[D", "h":
+		b.col = max(0, b.col-1)
+	case "\x1b[C", "l":
+		b.col = min(len(columnWidths(t))-1, b.col+1)
+	case "\x1b[5~":
+		b.row = max(0, b.row-page)
+	case "\x1b[6~":
+		b.row = min(len(t.Rows)-1, b.row+page)
+	case " ":
+		b.cols[b.col+1] = !b.cols[b.col+1]
+	}
+	return false, nil
+}
+
+func (b *browser) draw() {
+	var lines []string
+	if b.viewing {
+		lines = b.drawTable()
+	} else {
+		lines = b.drawList()
+	}
+	for len(lines) < b.height {
+		lines = append(lines, "")
+	}
+	fmt.Fprint(b.tty, "\x1b[H\x1b[2J"+strings.Join(lines[:b.height], "\r\n"))
+}
+
+func (b *browser) drawList() []string {
+	lines := []string{b.status("up/down: move  space: pick  enter: view  q: done  esc: cancel")}
+
+	listHeight := min(len(b.tables), max(3, (b.height-2)/2))
+	if b.cur < b.listTop {
+		b.listTop = b.cur
+	}
+	if b.cur >= b.listTop+listHeight {
+		b.listTop = b.cur - listHeight + 1
+	}
+
+	for i := b.listTop; i < min(len(b.tables), b.listTop+listHeight); i++ {
+		t := b.tables[i]
+		s := t.Summary(0)
+		mark := "[ ]"
+		if b.picked[i] {
+			mark = "[x]"
+		}
+		line := fmt.Sprintf("%s %3d  %dx%d", mark, t.Index, s.Rows, s.Columns)
+		if t.ID != "" {
+			line += "  id=" + t.ID
+		}
+		if t.Name != "" {
+			line += "  name=" + t.Name
+		}
+		if t.Caption != "" {
+			line += "  " + strconv.Quote(t.Caption)
+		}
+		line = truncate(line, b.width-2)
+		if i == b.cur {
+			line = "> \x1b[7m" + line + "\x1b[0m"
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, strings.Repeat("-", b.width))
+	return append(lines, b.grid(b.tables[b.cur], 0, 0, b.height-len(lines), false)...)
+}
+
+func (b *browser) drawTable() []string {
+	t := b.tables[b.cur]
+	height := b.height - 3 // status, column numbers and cell lines
+	widths := columnWidths(t)
+
+	if b.row < b.rowTop {
+		b.rowTop = b.row
+	}
+	if b.row >= b.rowTop+height {
+		b.rowTop = b.row - height + 1
+	}
+	if b.col < b.colLeft {
+		b.colLeft = b.col
+	}
+	for b.colLeft < b.col && !visible(widths, b.colLeft, b.col, b.width) {
+		b.colLeft++
+	}
+
+	pick := "space: pick column"
+	if b.cols[b.col+1] {
+		pick = "space: unpick column"
+	}
+	lines := []string{b.status(fmt.Sprintf("table %d  arrows: move  %s  t: pick table  enter: back  q: done", t.Index, pick))}
+	lines = append(lines, b.grid(t, b.rowTop, b.colLeft, height+1, true)...)
+	for len(lines) < b.height-1 {
+		lines = append(lines, "")
+	}
+
+	var cell string
+	if b.row < len(t.Rows) && b.col < len(t.Rows[b.row]) {
+		cell = t.Rows[b.row][b.col]
+	}
+	return append(lines, truncate(fmt.Sprintf("[%d,%d] %s", b.row+1, b.col+1, flatten(cell)), b.width))
+}
+
+func (b *browser) status(help string) string {
+	var picked []string
+	for i, t := range b.tables {
+		if b.picked[i] {
+			picked = append(picked, strconv.Itoa(t.Index))
+		}
+	}
+	var cols []string
+	for _, c := range slices.Sorted(mapKeys(b.cols)) {
+		cols = append(cols, strconv.Itoa(c))
+	}
+	if len(cols) == 0 {
+		cols = []string{"all"}
+	}
+	if len(picked) == 0 {
+		picked = []string{"current"}
+	}
+	s := fmt.Sprintf("tables: %s  columns: %s | %s", strings.Join(picked, ","), strings.Join(cols, ","), help)
+	return "\x1b[1m" + truncate(s, b.width) + "\x1b[0m"
+}
+
+// grid renders the rows of t starting at row top and column left.
+// The first line shows the column numbers, marking picked columns.
+func (b *browser) grid(t htmltable.Table, top, left, height int, cursor bool) []string {
+	if height <= 0 {
+		return nil
+	}
+	widths := columnWidths(t)
+
+	var lines []string
+	var sb strings.Builder
+	used := 0
+	for c := left; c < len(widths) && used+widths[c] <= b.width; c++ {
+		label := "#" + strconv.Itoa(c+1)
+		if b.cols[c+1] {
+			label += "*"
+		}
+		sb.WriteString(pad(truncate(label, widths[c]), widths[c]) + " ")
+		used += widths[c] + 1
+	}
+	lines = append(lines, sb.String())
+
+	for r := top; r < len(t.Rows) && len(lines) < height; r++ {
+		sb.Reset()
+		used = 0
+		for c := left; c < len(widths) && used+widths[c] <= b.width; c++ {
+			var cell string
+			if c < len(t.Rows[r]) {
+				cell = flatten(t.Rows[r][c])
+			}
+			s := pad(truncate(cell, widths[c]), widths[c])
+			switch {
+			case cursor && r == b.row && c == b.col:
+				s = "\x1b[7m" + s + "\x1b[0m"
+			case b.cols[c+1]:
+				s = "\x1b[1m" + s + "\x1b[0m"
+			}
+			sb.WriteString(s + " ")
+			used += widths[c] + 1
+		}
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+// visible reports whether column col fits on screen when column left is the
+// first one shown.
+func visible(widths []int, left, col, width int) bool {
+	used := 0
+	for c := left; c <= col; c++ {
+		used += widths[c] + 1
+	}
+	return used <= width
+}
+
+func columnWidths(t htmltable.Table) []int {
+	var widths []int
+	for _, row := range t.Rows {
+		for c, cell := range row {
+			if c >= len(widths) {
+				widths = append(widths, 3)
+			}
+			widths[c] = max(widths[c], min(maxCellWidth, htmltable.StringWidth(flatten(cell))))
+		}
+	}
+	return widths
+}
+
+func flatten(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return htmltable.TruncateWidth(s, width, "…")
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-htmltable.StringWidth(s)))
+}
+
+// commandLine returns the html2csv command line equivalent to the flags
+// given, with the table and column selections replaced.
+func commandLine(tableSel, colSel string) string {
+	args := []string{"html2csv"}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "interactive", "table", "columns":
+			return
+		}
+		if f.Value.Type() == "bool" && f.Value.String() == "true" {
+			args = append(args, "--"+f.Name)
+		} else {
+			args = append(args, shellQuote("--"+f.Name+"="+f.Value.String()))
+		}
+	})
+	if tableSel != "" {
+		args = append(args, "-t", shellQuote(tableSel))
+	}
+	if colSel != "" {
+		args = append(args, "-c", shellQuote(colSel))
+	}
+	for _, arg := range flag.Args() {
+		args = append(args, shellQuote(arg))
+	}
+	return strings.Join(args, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,224 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+	"github.com/ricardobranco777/html2csv/htmltable/sqlite"
+)
+
+// httpClient fetches URLs, so that an unresponsive server cannot block
+// --watch forever.
+var httpClient = &http.Client{Timeout: htmltable.DefaultTimeout}
+
+// job reads, converts and writes the selected tables of src.
+// If state or watching is set, output is only written when the
+// contents of the selected tables change.
+type job struct {
+	src        string
+	parser     *htmltable.Parser
+	pager      *htmltable.Paginator
+	sel        htmltable.Selector
+	cols       htmltable.ColumnSelector
+	transpose  string
+	merge      bool
+	source     string
+	splitBy    string
+	skipHeader bool
+	enc        htmltable.Encoder
+	output     string
+	state      string
+	hook       string
+	watching   bool
+
+	last string
+}
+
+func (j *job) run() error {
+	tables, err := j.load()
+	if err != nil {
+		return err
+	}
+	return j.emit(tables)
+}
+
+// load parses the input and returns all tables.
+func (j *job) load() ([]htmltable.Table, error) {
+	if j.pager != nil {
+		return j.pager.Parse(j.src)
+	}
+	in, err := openInput(j.src)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	return j.parser.ParseArchive(in)
+}
+
+// transform selects tables and applies the transpose, merge and split
+// options, which come before the column selection.
+func (j *job) transform(tables []htmltable.Table) ([]htmltable.Table, error) {
+	tables = j.sel.Apply(tables)
+	if j.transpose != "" {
+		out := make([]htmltable.Table, len(tables))
+		for i, t := range tables {
+			if t.RowHeaders || j.transpose == "always" {
+				t = t.Transpose()
+			}
+			out[i] = t
+		}
+		tables = out
+	}
+	if j.merge && len(tables) > 0 {
+		tables = []htmltable.Table{htmltable.Merge(tables, j.source)}
+	}
+	if j.splitBy != "" {
+		var out []htmltable.Table
+		for _, t := range tables {
+			parts, err := t.SplitBy(j.splitBy)
+			if err != nil {
+				return nil, fmt.Errorf("table %d: %w", t.Index, err)
+			}
+			out = append(out, parts...)
+		}
+		tables = out
+	}
+	return tables, nil
+}
+
+// emit writes the selected tables and runs the hook.
+func (j *job) emit(tables []htmltable.Table) error {
+	tables, err := j.transform(tables)
+	if err != nil {
+		return err
+	}
+	tables = j.cols.Apply(tables)
+	if j.skipHeader {
+		tables = htmltable.SkipHeader(tables)
+	}
+
+	var sum string
+	if j.state != "" || j.watching {
+		sum = hashTables(tables)
+		if j.last == "" && j.state != "" {
+			if j.last, err = readState(j.state); err != nil {
+				return err
+			}
+		}
+		if sum == j.last {
+			return nil
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := j.enc.Encode(&buf, tables); err != nil {
+		return err
+	}
+	if err := j.write(buf.Bytes()); err != nil {
+		return err
+	}
+	if j.hook != "" {
+		if err := runHook(j.hook, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	j.last = sum
+	if j.state != "" {
+		return os.WriteFile(j.state, []byte(sum+"\n"), 0o644)
+	}
+	return nil
+}
+
+// write writes data to the output file, or to standard output unless the
+// hook receives it instead.
+func (j *job) write(data []byte) error {
+	if j.output == "" {
+		if j.hook != "" {
+			return nil
+		}
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if _, ok := j.enc.(*sqlite.Encoder); ok {
+		return nil // written by the encoder
+	}
+	return os.WriteFile(j.output, data, 0o644)
+}
+
+// openInput opens a local file, an http(s) URL, or standard input if src is empty.
+func openInput(src string) (io.ReadCloser, error) {
+	if src == "" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return os.Open(src)
+	}
+
+	resp, err := httpClient.Get(src)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", src, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// hashTables returns a hex-encoded SHA-256 hash of the table contents.
+func hashTables(tables []htmltable.Table) string {
+	h := sha256.New()
+	var n [8]byte
+	field := func(s string) {
+		binary.BigEndian.PutUint64(n[:], uint64(len(s)))
+		h.Write(n[:])
+		io.WriteString(h, s)
+	}
+	for _, t := range tables {
+		field(t.ID)
+		field(t.Name)
+		binary.BigEndian.PutUint64(n[:], uint64(len(t.Rows)))
+		h.Write(n[:])
+		for _, row := range t.Rows {
+			binary.BigEndian.PutUint64(n[:], uint64(len(row)))
+			h.Write(n[:])
+			for _, cell := range row {
+				field(cell)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func readState(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data)), err
+}
+
+// runHook runs command with the shell, passing the output on standard input.
+func runHook(command string, output []byte) error {
+	cmd := exec.Command("/bin/sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// archivePeek is how much input is examined to detect an archive.
+const archivePeek = 16 << 10
+
+// ParseArchive is like Parse but also reads web archives.
+func ParseArchive(r io.Reader) ([]Table, error) {
+	return NewParser().ParseArchive(r)
+}
+
+// ParseArchive extracts the tables of every HTML document in a MIME
+// multipart archive, as saved by browsers in .mht and .mhtml files,
+// or in a WARC file. Other input is parsed as a single HTML document.
+// Tables are numbered across documents in archive order.
+// Compressed input is decompressed first, as with Decompress.
+func (p *Parser) ParseArchive(r io.Reader) ([]Table, error) {
+	var tables []Table
+	err := readDocuments(r, "", func(doc io.Reader) error {
+		ts, err := p.Parse(doc)
+		if err != nil {
+			return err
+		}
+		tables = appendNumbered(tables, ts)
+		return nil
+	})
+	return tables, err
+}
+
+// appendNumbered appends ts to tables, numbering them after the last table.
+func appendNumbered(tables, ts []Table) []Table {
+	offset := 0
+	if len(tables) > 0 {
+		offset = tables[len(tables)-1].Index
+	}
+	for _, t := range ts {
+		t.Index += offset
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// readDocuments calls yield with every HTML document in r.
+// Input that is not an archive is converted to UTF-8 with the charset of
+// contentType, which may be empty.
+func readDocuments(r io.Reader, contentType string, yield func(io.Reader) error) error {
+	zr, err := Decompress(r)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	br := bufio.NewReaderSize(zr, archivePeek)
+	data, _ := br.Peek(archivePeek)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("WARC/")):
+		return readWARC(br, yield)
+	case isMIME(data):
+		h, err := textproto.NewReader(br).ReadMIMEHeader()
+		if err != nil {
+			return err
+		}
+		return readMIMEPart(h, br, yield)
+	}
+	return yield(decodeCharset(contentType, br))
+}
+
+var headerLine = regexp.MustCompile(`^[!-9;-~]+:`)
+
+// isMIME reports whether data starts with the header of a MIME message
+// holding HTML.
+func isMIME(data []byte) bool {
+	if !headerLine.Match(data) {
+		return false
+	}
+	h, _ := textproto.NewReader(bufio.NewReader(bytes.NewReader(data))).ReadMIMEHeader()
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "multipart/") || h.Get("Mime-Version") != "" && isHTML(mediaType)
+}
+
+func isHTML(mediaType string) bool {
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func readMIMEPart(h textproto.MIMEHeader, body io.Reader, yield func(io.Reader) error) error {
+	switch strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	contentType := h.Get("Content-Type")
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return yieldHTML(contentType, body, yield)
+	}
+	if params["boundary"] == "" {
+		return errors.New("multipart message without boundary")
+	}
+
+	mr := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := mr.NextRawPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := readMIMEPart(part.Header, part, yield); err != nil {
+			return err
+		}
+	}
+}
+
+// readWARC yields the HTML documents of the response and resource records
+// of a WARC file. Compressed WARC files must be decompressed first.
+func readWARC(br *bufio.Reader, yield func(io.Reader) error) error {
+	tp := textproto.NewReader(br)
+	for {
+		line, err := tp.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "WARC/") {
+			return fmt.Errorf("invalid WARC record: %q", line)
+		}
+
+		h, err := tp.ReadMIMEHeader()
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid WARC Content-Length %q", h.Get("Content-Length"))
+		}
+
+		block := io.LimitReader(br, n)
+		if err := readWARCRecord(h, block, yield); err != nil {
+			return fmt.Errorf("WARC record %s: %w", h.Get("WARC-Record-ID"), err)
+		}
+		if _, err := io.Copy(io.Discard, block); err != nil {
+			return err
+		}
+	}
+}
+
+func readWARCRecord(h textproto.MIMEHeader, block io.Reader, yield func(io.Reader) error) error {
+	switch h.Get("WARC-Type") {
+	case "resource":
+		return yieldHTML(h.Get("Content-Type"), block, yield)
+	case "response":
+		mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+		if mediaType != "application/http" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(block), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return nil
+	}
+
+	body := io.Reader(resp.Body)
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil
+	}
+	return yieldHTML(resp.Header.Get("Content-Type"), body, yield)
+}
+
+// yieldHTML calls yield with body converted to UTF-8 if contentType is HTML.
+func yieldHTML(contentType string, body io.Reader, yield func(io.Reader) error) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isHTML(mediaType) {
+		return nil
+	}
+	return yield(decodeCharset(contentType, body))
+}
+
+// decodeCharset returns body converted to UTF-8 from the charset of
+// contentType. Unknown charsets are read as UTF-8.
+func decodeCharset(contentType string, body io.Reader) io.Reader {
+	_, params, _ := mime.ParseMediaType(contentType)
+	if label := params["charset"]; label != "" && !strings.EqualFold(label, "utf-8") {
+		if r, err := charset.NewReaderLabel(label, body); err == nil {
+			return r
+		}
+	}
+	return body
+}
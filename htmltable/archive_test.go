@@ -0,0 +1,111 @@
+package htmltable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseArchive_MHTML(t *testing.T) {
+	crlf := func(s string) string { return strings.ReplaceAll(s, "\n", "\r\n") }
+	mhtml := crlf(`From: <Saved by Blink>
+Snapshot-Content-Location: https://example.com/
+Subject: Example
+MIME-Version: 1.0
+Content-Type: multipart/related;
+	type="text/html";
+	boundary="----MultipartBoundary--abc----"
+
+
+------MultipartBoundary--abc----
+Content-Type: text/html
+Content-ID: <frame-1@mhtml.blink>
+Content-Transfer-Encoding: quoted-printable
+Content-Location: https://example.com/
+
+<html><body><table id=3D"a"><tr><th>Name</th><th>Price</th></tr><tr><td>appl=
+e</td><td>1</td></tr></table></body></html>
+------MultipartBoundary--abc----
+Content-Type: text/css
+Content-Transfer-Encoding: quoted-printable
+Content-Location: https://example.com/style.css
+
+table { color: red; }
+------MultipartBoundary--abc----
+Content-Type: text/html; charset=iso-8859-1
+Content-Transfer-Encoding: base64
+Content-Location: cid:frame-2@mhtml.blink
+
+PHRhYmxlPjx0cj48dGQ+Y2Fm6TwvdGQ+PC90cj48L3RhYmxlPg==
+------MultipartBoundary--abc------
+`)
+
+	tables, err := ParseArchive(strings.NewReader(mhtml))
+	if err != nil {
+		t.Fatalf("ParseArchive error: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d: %+v", len(tables), tables)
+	}
+	if tables[0].ID != "a" || tables[0].Index != 1 || tables[1].Index != 2 {
+		t.Fatalf("unexpected metadata: %+v", tables)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"Name", "Price"}, {"apple", "1"}}, "table 1")
+	assertRowsEqual(t, tables[1].Rows, [][]string{{"café"}}, "table 2")
+}
+
+func TestParseArchive_WARC(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("<table><tr><td>zipped</td></tr></table>"))
+	zw.Close()
+
+	record := func(typ, contentType, block string) string {
+		return fmt.Sprintf("WARC/1.1\r\nWARC-Type: %s\r\nWARC-Record-ID: <urn:uuid:1>\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+			typ, contentType, len(block), block)
+	}
+	warc := record("warcinfo", "application/warc-fields", "software: test\r\n") +
+		record("request", "application/http; msgtype=request", "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n") +
+		record("response", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: text/html; charset=utf-8\r\nTransfer-Encoding: chunked\r\n\r\n"+
+				"1c\r\n<table><tr><td>one</td></tr>\r\n8\r\n</table>\r\n0\r\n\r\n") +
+		record("response", "application/http; msgtype=response",
+			"HTTP/1.1 301 Moved Permanently\r\nContent-Type: text/html\r\nContent-Length: 38\r\n\r\n<table><tr><td>moved</td></tr></table>") +
+		record("response", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Encoding: gzip\r\n\r\n"+gz.String()) +
+		record("resource", "text/html", "<table><tr><td>resource</td></tr></table>") +
+		record("resource", "image/png", "\x89PNG")
+
+	tables, err := ParseArchive(strings.NewReader(warc))
+	if err != nil {
+		t.Fatalf("ParseArchive error: %v", err)
+	}
+	var got []string
+	for _, tbl := range tables {
+		got = append(got, fmt.Sprintf("%d:%s", tbl.Index, tbl.Rows[0][0]))
+	}
+	assertSliceEqual(t, got, []string{"1:one", "2:zipped", "3:resource"}, "tables")
+}
+
+func TestParseArchive_HTML(t *testing.T) {
+	for _, doc := range []string{
+		"<table><tr><td>x</td></tr></table>",
+		"Note: not a header\n<table><tr><td>x</td></tr></table>",
+	} {
+		tables, err := ParseArchive(strings.NewReader(doc))
+		if err != nil {
+			t.Fatalf("ParseArchive error: %v", err)
+		}
+		if len(tables) != 1 || tables[0].Rows[0][0] != "x" {
+			t.Fatalf("unexpected tables for %q: %+v", doc, tables)
+		}
+	}
+}
+
+func TestParseArchive_InvalidWARC(t *testing.T) {
+	if _, err := ParseArchive(strings.NewReader("WARC/1.0\r\nWARC-Type: resource\r\n\r\n")); err == nil {
+		t.Fatal("expected error for missing Content-Length")
+	}
+}
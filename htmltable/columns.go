@@ -0,0 +1,75 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ColumnSelector selects columns by 1-based index or by header name.
+type ColumnSelector struct {
+	Indexes map[int]struct{}
+	Names   map[string]struct{}
+}
+
+// ParseColumnSelector parses a comma-separated list of column indexes
+// and/or header names.
+func ParseColumnSelector(s string) (ColumnSelector, error) {
+	sel := ColumnSelector{
+		Indexes: make(map[int]struct{}),
+		Names:   make(map[string]struct{}),
+	}
+
+	for part := range strings.SplitSeq(s, ",") {
+		p := strings.TrimSpace(part)
+		if p == "" {
+			continue
+		}
+
+		if i, err := strconv.Atoi(p); err == nil {
+			if i <= 0 {
+				return sel, errors.New("column index must be >= 1")
+			}
+			sel.Indexes[i] = struct{}{}
+		} else {
+			sel.Names[p] = struct{}{}
+		}
+	}
+
+	return sel, nil
+}
+
+// Apply keeps the selected columns of every table, in table order.
+// Names are matched against the first row of each table.
+func (s ColumnSelector) Apply(tables []Table) []Table {
+	if len(s.Indexes) == 0 && len(s.Names) == 0 {
+		return tables
+	}
+
+	out := make([]Table, 0, len(tables))
+	for _, t := range tables {
+		var keep []int
+		for i, name := range t.Header() {
+			_, byIndex := s.Indexes[i+1]
+			_, byName := s.Names[strings.TrimSpace(name)]
+			if byIndex || byName {
+				keep = append(keep, i)
+			}
+		}
+
+		rows := make([][]string, len(t.Rows))
+		for r, row := range t.Rows {
+			rows[r] = make([]string, len(keep))
+			for j, c := range keep {
+				if c < len(row) {
+					rows[r][j] = row[c]
+				}
+			}
+		}
+		t.Rows = rows
+		out = append(out, t)
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package htmltable
+
+import "testing"
+
+func TestParseColumnSelector(t *testing.T) {
+	sel, err := ParseColumnSelector(" 3, Price ,,1")
+	if err != nil {
+		t.Fatalf("ParseColumnSelector error: %v", err)
+	}
+	if len(sel.Indexes) != 2 || len(sel.Names) != 1 {
+		t.Fatalf("unexpected selector: %+v", sel)
+	}
+	if _, ok := sel.Names["Price"]; !ok {
+		t.Fatalf("expected name %q, got %+v", "Price", sel.Names)
+	}
+
+	if _, err := ParseColumnSelector("0"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestColumnSelectorApply(t *testing.T) {
+	tables := []Table{
+		{Index: 1, Rows: [][]string{{"Product", "Price", "Stock"}, {"a", "1", "yes"}}},
+		{Index: 2, Rows: [][]string{{"Name", "Size"}, {"x", "2"}}},
+	}
+
+	sel, err := ParseColumnSelector("Stock,1")
+	if err != nil {
+		t.Fatalf("ParseColumnSelector error: %v", err)
+	}
+	got := sel.Apply(tables)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(got))
+	}
+	assertRowsEqual(t, got[0].Rows, [][]string{{"Product", "Stock"}, {"a", "yes"}}, "table 1")
+	assertRowsEqual(t, got[1].Rows, [][]string{{"Name"}, {"x"}}, "table 2")
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"Product", "Price", "Stock"}, {"a", "1", "yes"}}, "input")
+}
+
+func TestColumnSelectorApply_EmptySelectorReturnsInput(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a", "b"}}}}
+	sel, _ := ParseColumnSelector("")
+	got := sel.Apply(tables)
+	assertRowsEqual(t, got[0].Rows, tables[0].Rows, "rows")
+}
@@ -0,0 +1,86 @@
+package htmltable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const compressTestDoc = "<table><tr><td>x</td></tr></table>"
+
+func TestDecompress(t *testing.T) {
+	compressed := map[string][]byte{
+		"none":  []byte(compressTestDoc),
+		"bzip2": []byte("\x42\x5a\x68\x39\x31\x41\x59\x26\x53\x59\x75\x59\x71\x20\x00\x00\x03\x19\x80\x00\x00\x80\x05\x36\x04\x14\x40\x20\x00\x31\x00\xd3\x4d\x02\x54\xfd\x48\x0d\xa8\xe2\xa2\x17\xdf\x53\x50\xf0\x90\x85\x12\x2a\xdf\xc5\xdc\x91\x4e\x14\x24\x1d\x56\x5c\x48\x00"),
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	gw.Write([]byte(compressTestDoc))
+	gw.Close()
+	compressed["gzip"] = bytes.Clone(buf.Bytes())
+
+	buf.Reset()
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw.Write([]byte(compressTestDoc))
+	zw.Close()
+	compressed["zstd"] = bytes.Clone(buf.Bytes())
+
+	buf.Reset()
+	xw, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xw.Write([]byte(compressTestDoc))
+	xw.Close()
+	compressed["xz"] = bytes.Clone(buf.Bytes())
+
+	for name, data := range compressed {
+		r, err := Decompress(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: Decompress error: %v", name, err)
+		}
+		got, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("%s: read error: %v", name, err)
+		}
+		if string(got) != compressTestDoc {
+			t.Fatalf("%s: got %q", name, got)
+		}
+
+		tables, err := ParseArchive(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: ParseArchive error: %v", name, err)
+		}
+		if len(tables) != 1 || tables[0].Rows[0][0] != "x" {
+			t.Fatalf("%s: unexpected tables %+v", name, tables)
+		}
+	}
+}
+
+func TestDecompress_LooksLikeBzip2(t *testing.T) {
+	const doc = "BZh9 is not a bzip2 stream"
+	r, err := Decompress(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Decompress error: %v", err)
+	}
+	defer r.Close()
+	if got, _ := io.ReadAll(r); string(got) != doc {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestDecompress_Corrupt(t *testing.T) {
+	if _, err := ParseArchive(bytes.NewReader([]byte{0x1f, 0x8b, 0x08, 0x00})); err == nil {
+		t.Fatal("expected error for truncated gzip input")
+	}
+}
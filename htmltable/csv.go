@@ -0,0 +1,118 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// csvWriter writes records like encoding/csv.Writer, with a configurable
+// quote character, forced quoting and a NULL marker for empty fields.
+type csvWriter struct {
+	w        *bufio.Writer
+	comma    rune
+	quote    rune
+	quoteAll bool
+	useCRLF  bool
+	null     string
+}
+
+func validCSVRune(r rune) bool {
+	return r != 0 && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+func newCSVWriter(w io.Writer, e *CSVEncoder) (*csvWriter, error) {
+	quote := e.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+	if !validCSVRune(e.Comma) {
+		return nil, errors.New("invalid delimiter")
+	}
+	if !validCSVRune(quote) || quote == e.Comma {
+		return nil, errors.New("invalid quote character")
+	}
+	if strings.ContainsRune(e.Null, e.Comma) || strings.ContainsRune(e.Null, quote) || strings.ContainsAny(e.Null, "\r\n") {
+		return nil, errors.New("NULL marker must not contain the delimiter, quotes or newlines")
+	}
+
+	return &csvWriter{
+		w:        bufio.NewWriter(w),
+		comma:    e.Comma,
+		quote:    quote,
+		quoteAll: e.QuoteAll,
+		useCRLF:  e.UseCRLF,
+		null:     e.Null,
+	}, nil
+}
+
+func (w *csvWriter) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return w.quoteAll && w.null == ""
+	}
+	if w.quoteAll || field == `\.` || w.null != "" && field == w.null {
+		return true
+	}
+	if strings.ContainsRune(field, w.comma) || strings.ContainsRune(field, w.quote) || strings.ContainsAny(field, "\r\n") {
+		return true
+	}
+
+	r1, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r1)
+}
+
+// write writes a record. Errors are reported by flush.
+func (w *csvWriter) write(record []string) {
+	for n, field := range record {
+		if n > 0 {
+			w.w.WriteRune(w.comma)
+		}
+
+		if field == "" && w.null != "" {
+			w.w.WriteString(w.null)
+			continue
+		}
+		if !w.fieldNeedsQuotes(field) {
+			w.w.WriteString(field)
+			continue
+		}
+
+		w.w.WriteRune(w.quote)
+		for _, r := range field {
+			switch r {
+			case w.quote:
+				w.w.WriteRune(r)
+				w.w.WriteRune(r)
+			case '\r':
+				if !w.useCRLF {
+					w.w.WriteByte('\r')
+				}
+			case '\n':
+				w.newline()
+			default:
+				w.w.WriteRune(r)
+			}
+		}
+		w.w.WriteRune(w.quote)
+	}
+
+	w.newline()
+}
+
+func (w *csvWriter) newline() {
+	if w.useCRLF {
+		w.w.WriteString("\r\n")
+	} else {
+		w.w.WriteByte('\n')
+	}
+}
+
+// flush writes any buffered data and returns the first write error.
+func (w *csvWriter) flush() error {
+	return w.w.Flush()
+}
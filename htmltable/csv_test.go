@@ -0,0 +1,80 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCSVEncoder_Encode_MatchesEncodingCSV(t *testing.T) {
+	rows := [][]string{
+		{"plain", "", "with,comma", `with "quotes"`},
+		{" leading space", "\ttab", "multi\nline", "cr\r\nlf"},
+		{`\.`, "ünïcödé", "end"},
+	}
+
+	for _, comma := range []rune{',', ';', '\t', '§'} {
+		for _, crlf := range []bool{false, true} {
+			var want bytes.Buffer
+			cw := csv.NewWriter(&want)
+			cw.Comma = comma
+			cw.UseCRLF = crlf
+			cw.WriteAll(rows)
+
+			var got bytes.Buffer
+			enc := NewCSVEncoder()
+			enc.Comma = comma
+			enc.UseCRLF = crlf
+			if err := enc.Encode(&got, []Table{{Rows: rows}}); err != nil {
+				t.Fatalf("Encode error: %v", err)
+			}
+			if got.String() != want.String() {
+				t.Fatalf("comma %q, crlf %v: got\n%q\nwant\n%q", comma, crlf, got.String(), want.String())
+			}
+		}
+	}
+}
+
+func TestCSVEncoder_Encode_Options(t *testing.T) {
+	tables := []Table{{Rows: [][]string{
+		{"a", "", "it's", "NULL"},
+	}}}
+
+	tests := []struct {
+		name string
+		enc  CSVEncoder
+		want string
+	}{
+		{"quote all", CSVEncoder{Comma: ',', QuoteAll: true}, `"a","","it's","NULL"` + "\n"},
+		{"quote char", CSVEncoder{Comma: ',', Quote: '\''}, `a,,'it''s',NULL` + "\n"},
+		{"null", CSVEncoder{Comma: ',', Null: "NULL"}, `a,NULL,it's,"NULL"` + "\n"},
+		{"null quote all", CSVEncoder{Comma: ',', Null: `\N`, QuoteAll: true}, `"a",\N,"it's","NULL"` + "\n"},
+		{"bom crlf", CSVEncoder{Comma: ';', BOM: true, UseCRLF: true}, "\uFEFFa;;it's;NULL\r\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := tt.enc.Encode(&buf, tables); err != nil {
+			t.Fatalf("%s: Encode error: %v", tt.name, err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.name, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestCSVEncoder_Encode_InvalidDialect(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a"}}}}
+	for _, enc := range []CSVEncoder{
+		{Comma: 0},
+		{Comma: '\n'},
+		{Comma: ',', Quote: ','},
+		{Comma: ',', Quote: '\r'},
+		{Comma: ',', Null: "a,b"},
+	} {
+		var buf bytes.Buffer
+		if err := enc.Encode(&buf, tables); err == nil {
+			t.Fatalf("%+v: expected error, got nil", enc)
+		}
+	}
+}
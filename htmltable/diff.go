@@ -0,0 +1,210 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Change is a row that differs between two tables.
+// Old is nil for added rows and New is nil for removed rows.
+// Columns lists the names of the changed columns of changed rows.
+type Change struct {
+	Kind    ChangeKind
+	Key     string
+	Old     []string
+	New     []string
+	Columns []string
+}
+
+// TableDiff holds the row changes between two versions of a table.
+// Rows are laid out by Header, the union of the column names of both
+// tables, in the order of the new table.
+type TableDiff struct {
+	Key     string
+	Header  []string
+	Changes []Change
+}
+
+// Diff compares the rows of two versions of a table by the value of the
+// key column.
+// The first row of each table is the header, and columns are matched by
+// name as in Records. Changed and added rows are reported in the order of
+// after, followed by removed rows in the order of before.
+func Diff(before, after Table, key string) (TableDiff, error) {
+	if len(before.Rows) == 0 || len(after.Rows) == 0 {
+		return TableDiff{}, errors.New("cannot diff tables without a header")
+	}
+
+	oldNames := uniqueNames(before.Rows[0])
+	newNames := uniqueNames(after.Rows[0])
+
+	header := slices.Clone(newNames)
+	for _, name := range oldNames {
+		if !slices.Contains(header, name) {
+			header = append(header, name)
+		}
+	}
+
+	d := TableDiff{Key: key, Header: header}
+
+	oldRows, err := keyedRows(before.Rows[1:], oldNames, header, key)
+	if err != nil {
+		return TableDiff{}, fmt.Errorf("old table: %w", err)
+	}
+	newRows, err := keyedRows(after.Rows[1:], newNames, header, key)
+	if err != nil {
+		return TableDiff{}, fmt.Errorf("new table: %w", err)
+	}
+
+	seen := make(map[string]bool, len(newRows.rows))
+	for i, row := range newRows.rows {
+		k := newRows.keys[i]
+		seen[k] = true
+
+		j, ok := oldRows.index[k]
+		if !ok {
+			d.Changes = append(d.Changes, Change{Kind: Added, Key: k, New: row})
+			continue
+		}
+
+		var columns []string
+		for c, name := range header {
+			if row[c] != oldRows.rows[j][c] {
+				columns = append(columns, name)
+			}
+		}
+		if len(columns) > 0 {
+			d.Changes = append(d.Changes, Change{Kind: Changed, Key: k, Old: oldRows.rows[j], New: row, Columns: columns})
+		}
+	}
+
+	for i, row := range oldRows.rows {
+		if k := oldRows.keys[i]; !seen[k] {
+			d.Changes = append(d.Changes, Change{Kind: Removed, Key: k, Old: row})
+		}
+	}
+
+	return d, nil
+}
+
+type keyed struct {
+	rows  [][]string
+	keys  []string
+	index map[string]int
+}
+
+// keyedRows lays out rows by header and indexes them by the key column.
+func keyedRows(rows [][]string, names, header []string, key string) (keyed, error) {
+	col := slices.Index(names, key)
+	if col < 0 {
+		return keyed{}, fmt.Errorf("no column %q", key)
+	}
+
+	pos := make([]int, len(names))
+	for i, name := range names {
+		pos[i] = slices.Index(header, name)
+	}
+
+	out := keyed{index: make(map[string]int, len(rows))}
+	for _, row := range rows {
+		var k string
+		if col < len(row) {
+			k = row[col]
+		}
+		if _, dup := out.index[k]; dup {
+			return keyed{}, fmt.Errorf("duplicate key %q", k)
+		}
+
+		r := make([]string, len(header))
+		for i, v := range row {
+			if i < len(pos) {
+				r[pos[i]] = v
+			}
+		}
+
+		out.index[k] = len(out.rows)
+		out.rows = append(out.rows, r)
+		out.keys = append(out.keys, k)
+	}
+	return out, nil
+}
+
+// Table returns the changes as a table with a leading "change" column.
+// Added and removed rows appear once, and changed rows appear twice,
+// as an "old" row followed by a "new" row.
+func (d TableDiff) Table() Table {
+	rows := [][]string{append([]string{"change"}, d.Header...)}
+	for _, c := range d.Changes {
+		switch c.Kind {
+		case Added:
+			rows = append(rows, append([]string{string(Added)}, c.New...))
+		case Removed:
+			rows = append(rows, append([]string{string(Removed)}, c.Old...))
+		case Changed:
+			rows = append(rows,
+				append([]string{"old"}, c.Old...),
+				append([]string{"new"}, c.New...))
+		}
+	}
+	return Table{Rows: rows}
+}
+
+// MarshalJSON encodes the diff as an object with "added", "removed" and
+// "changed" arrays, where rows are objects keyed by column name.
+func (d TableDiff) MarshalJSON() ([]byte, error) {
+	type changed struct {
+		Key     string            `json:"key"`
+		Columns []string          `json:"columns"`
+		Old     map[string]string `json:"old"`
+		New     map[string]string `json:"new"`
+	}
+	out := struct {
+		Key     string              `json:"key"`
+		Added   []map[string]string `json:"added"`
+		Removed []map[string]string `json:"removed"`
+		Changed []changed           `json:"changed"`
+	}{
+		Key:     d.Key,
+		Added:   []map[string]string{},
+		Removed: []map[string]string{},
+		Changed: []changed{},
+	}
+
+	record := func(row []string) map[string]string {
+		m := make(map[string]string, len(d.Header))
+		for i, name := range d.Header {
+			m[name] = row[i]
+		}
+		return m
+	}
+
+	for _, c := range d.Changes {
+		switch c.Kind {
+		case Added:
+			out.Added = append(out.Added, record(c.New))
+		case Removed:
+			out.Removed = append(out.Removed, record(c.Old))
+		case Changed:
+			out.Changed = append(out.Changed, changed{
+				Key:     c.Key,
+				Columns: c.Columns,
+				Old:     record(c.Old),
+				New:     record(c.New),
+			})
+		}
+	}
+
+	return json.Marshal(out)
+}
@@ -0,0 +1,120 @@
+package htmltable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiff_AddedRemovedChanged(t *testing.T) {
+	before := Table{Rows: [][]string{
+		{"Product", "Price", "Version"},
+		{"a", "1.00", "1.0"},
+		{"b", "2.00", "2.0"},
+		{"c", "3.00", "3.0"},
+	}}
+	after := Table{Rows: [][]string{
+		{"Product", "Price", "Stock"},
+		{"d", "4.00", "yes"},
+		{"a", "1.00", ""},
+		{"b", "2.50", ""},
+	}}
+
+	d, err := Diff(before, after, "Product")
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+
+	assertSliceEqual(t, d.Header, []string{"Product", "Price", "Stock", "Version"}, "Header")
+
+	if len(d.Changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d: %+v", len(d.Changes), d.Changes)
+	}
+
+	// d is added, a lost its Version, b changed price and lost its Version, c is removed
+	want := []struct {
+		kind ChangeKind
+		key  string
+	}{{Added, "d"}, {Changed, "a"}, {Changed, "b"}, {Removed, "c"}}
+	for i, w := range want {
+		if d.Changes[i].Kind != w.kind || d.Changes[i].Key != w.key {
+			t.Fatalf("change[%d]: got %s %q, want %s %q", i, d.Changes[i].Kind, d.Changes[i].Key, w.kind, w.key)
+		}
+	}
+	assertSliceEqual(t, d.Changes[2].Columns, []string{"Price", "Version"}, "changed columns")
+	assertSliceEqual(t, d.Changes[2].Old, []string{"b", "2.00", "", "2.0"}, "old")
+	assertSliceEqual(t, d.Changes[2].New, []string{"b", "2.50", "", ""}, "new")
+
+	tab := d.Table()
+	want0 := [][]string{
+		{"change", "Product", "Price", "Stock", "Version"},
+		{"added", "d", "4.00", "yes", ""},
+		{"old", "a", "1.00", "", "1.0"},
+		{"new", "a", "1.00", "", ""},
+		{"old", "b", "2.00", "", "2.0"},
+		{"new", "b", "2.50", "", ""},
+		{"removed", "c", "3.00", "", "3.0"},
+	}
+	assertRowsEqual(t, tab.Rows, want0, "Table")
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	tab := Table{Rows: [][]string{{"K", "V"}, {"1", "x"}}}
+	d, err := Diff(tab, tab, "K")
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+	if len(d.Changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", d.Changes)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	tab := Table{Rows: [][]string{{"K"}, {"1"}}}
+	dup := Table{Rows: [][]string{{"K"}, {"1"}, {"1"}}}
+
+	tests := []struct {
+		before, after Table
+		key           string
+	}{
+		{tab, tab, "missing"},
+		{Table{}, tab, "K"},
+		{dup, tab, "K"},
+		{tab, dup, "K"},
+	}
+	for i, tt := range tests {
+		if _, err := Diff(tt.before, tt.after, tt.key); err == nil {
+			t.Fatalf("case %d: expected error, got nil", i)
+		}
+	}
+}
+
+func TestTableDiff_MarshalJSON(t *testing.T) {
+	before := Table{Rows: [][]string{{"K", "V"}, {"1", "a"}, {"2", "b"}}}
+	after := Table{Rows: [][]string{{"K", "V"}, {"1", "A"}, {"3", "c"}}}
+
+	d, err := Diff(before, after, "K")
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	want := `{"key":"K",` +
+		`"added":[{"K":"3","V":"c"}],` +
+		`"removed":[{"K":"2","V":"b"}],` +
+		`"changed":[{"key":"1","columns":["V"],"old":{"K":"1","V":"a"},"new":{"K":"1","V":"A"}}]}`
+	if string(b) != want {
+		t.Fatalf("unexpected JSON:\n%s\nwant:\n%s", b, want)
+	}
+
+	b, err = json.Marshal(TableDiff{Key: "K"})
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(b) != `{"key":"K","added":[],"removed":[],"changed":[]}` {
+		t.Fatalf("unexpected JSON for empty diff: %s", b)
+	}
+}
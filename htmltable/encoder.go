@@ -0,0 +1,72 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Encoder writes tables in an output format.
+type Encoder interface {
+	Encode(w io.Writer, tables []Table) error
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = make(map[string]func() Encoder)
+)
+
+func init() {
+	RegisterEncoder("csv", func() Encoder { return NewCSVEncoder() })
+	RegisterEncoder("html", func() Encoder { return NewHTMLEncoder() })
+	RegisterEncoder("json", func() Encoder { return NewJSONEncoder() })
+	RegisterEncoder("parquet", func() Encoder { return NewParquetEncoder() })
+	RegisterEncoder("sql", func() Encoder { return NewSQLEncoder() })
+	RegisterEncoder("text", func() Encoder { return NewTextEncoder() })
+	RegisterEncoder("toml", func() Encoder { return NewTOMLEncoder() })
+	RegisterEncoder("yaml", func() Encoder { return NewYAMLEncoder() })
+}
+
+// RegisterEncoder makes an output format available by name to NewEncoder.
+// Packages providing formats usually call it from an init function.
+// It panics if newEncoder is nil or if name is already registered.
+func RegisterEncoder(name string, newEncoder func() Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+
+	if newEncoder == nil {
+		panic("htmltable: RegisterEncoder with nil constructor for " + name)
+	}
+	if _, dup := encoders[name]; dup {
+		panic("htmltable: RegisterEncoder called twice for " + name)
+	}
+	encoders[name] = newEncoder
+}
+
+// NewEncoder returns a new encoder for the named format with its default settings.
+func NewEncoder(name string) (Encoder, error) {
+	encodersMu.RLock()
+	newEncoder, ok := encoders[name]
+	encodersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", name)
+	}
+	return newEncoder(), nil
+}
+
+// Formats returns the sorted names of the registered formats.
+func Formats() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,72 @@
+package htmltable
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+)
+
+type nameEncoder struct{}
+
+func (nameEncoder) Encode(w io.Writer, tables []Table) error {
+	for _, t := range tables {
+		if _, err := io.WriteString(w, t.Name+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNewEncoder_BuiltinFormats(t *testing.T) {
+	for _, name := range []string{"csv", "html", "json", "parquet", "sql", "text", "toml", "yaml"} {
+		enc, err := NewEncoder(name)
+		if err != nil {
+			t.Fatalf("NewEncoder(%q) error: %v", name, err)
+		}
+		if enc == nil {
+			t.Fatalf("NewEncoder(%q) returned nil", name)
+		}
+	}
+
+	enc, _ := NewEncoder("csv")
+	if c, ok := enc.(*CSVEncoder); !ok || c.Comma != ',' {
+		t.Fatalf("expected a default *CSVEncoder, got %#v", enc)
+	}
+
+	if _, err := NewEncoder("nope"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("test-names", func() Encoder { return nameEncoder{} })
+	t.Cleanup(func() {
+		encodersMu.Lock()
+		delete(encoders, "test-names")
+		encodersMu.Unlock()
+	})
+
+	if !slices.Contains(Formats(), "test-names") {
+		t.Fatalf("Formats() = %v, missing test-names", Formats())
+	}
+
+	enc, err := NewEncoder("test-names")
+	if err != nil {
+		t.Fatalf("NewEncoder error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, []Table{{Name: "a"}, {Name: "b"}}); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if buf.String() != "a\nb\n" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on duplicate registration")
+		}
+	}()
+	RegisterEncoder("csv", func() Encoder { return NewCSVEncoder() })
+}
@@ -0,0 +1,141 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"html"
+	"io"
+)
+
+const htmlStyle = `table { border-collapse: collapse; margin: 1em 0; font-family: sans-serif; font-size: 0.9em; }
+caption { font-weight: bold; text-align: left; padding: 0.3em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #f0f0f0; }
+tbody tr:nth-child(even) { background: #fafafa; }
+.num { text-align: right; }
+`
+
+const htmlSortStyle = `th { cursor: pointer; user-select: none; }
+th[aria-sort=ascending]::after { content: " \25B2"; }
+th[aria-sort=descending]::after { content: " \25BC"; }
+`
+
+// htmlSortScript sorts a table body by the clicked header cell,
+// numerically if both values are numbers.
+const htmlSortScript = `document.querySelectorAll("table").forEach(function (table) {
+  table.querySelectorAll("thead th").forEach(function (th, col) {
+    th.addEventListener("click", function () {
+      var asc = th.getAttribute("aria-sort") !== "ascending";
+      table.querySelectorAll("thead th").forEach(function (h) { h.removeAttribute("aria-sort"); });
+      th.setAttribute("aria-sort", asc ? "ascending" : "descending");
+      var num = function (s) { return parseFloat(s.replace(/[^0-9.eE+-]/g, "")); };
+      var tbody = table.tBodies[0];
+      Array.from(tbody.rows).sort(function (a, b) {
+        var x = a.cells[col].textContent, y = b.cells[col].textContent;
+        var nx = num(x), ny = num(y);
+        var c = isNaN(nx) || isNaN(ny) ? x.localeCompare(y) : nx - ny;
+        return asc ? c : -c;
+      }).forEach(function (row) { tbody.appendChild(row); });
+    });
+  });
+});
+`
+
+// HTMLEncoder writes tables as a minimal standalone HTML document.
+// The first row of every table is written as a header row unless Header
+// is false, and numeric columns are aligned to the right.
+type HTMLEncoder struct {
+	// Title is the document title.
+	Title string
+
+	// Header writes the first row of every table as a header row.
+	Header bool
+
+	// Sortable makes tables sortable by clicking on header cells.
+	Sortable bool
+}
+
+func NewHTMLEncoder() *HTMLEncoder {
+	return &HTMLEncoder{Title: "Tables", Header: true}
+}
+
+func (e *HTMLEncoder) Encode(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	bw.WriteString("<title>" + html.EscapeString(e.Title) + "</title>\n")
+	bw.WriteString("<style>\n" + htmlStyle)
+	if e.Sortable && e.Header {
+		bw.WriteString(htmlSortStyle)
+	}
+	bw.WriteString("</style>\n</head>\n<body>\n")
+
+	for _, t := range tables {
+		e.encodeTable(bw, t)
+	}
+
+	if e.Sortable && e.Header {
+		bw.WriteString("<script>\n" + htmlSortScript + "</script>\n")
+	}
+	bw.WriteString("</body>\n</html>\n")
+
+	return bw.Flush()
+}
+
+func (e *HTMLEncoder) encodeTable(w *bufio.Writer, t Table) {
+	if len(t.Rows) == 0 {
+		return
+	}
+
+	w.WriteString("<table")
+	if t.ID != "" {
+		w.WriteString(` id="` + html.EscapeString(t.ID) + `"`)
+	}
+	w.WriteString(">\n")
+
+	caption := t.Caption
+	if t.Section != "" {
+		if caption != "" {
+			caption += " – "
+		}
+		caption += t.Section
+	}
+	if caption != "" {
+		w.WriteString("<caption>" + html.EscapeString(caption) + "</caption>\n")
+	}
+
+	numeric := make(map[int]bool)
+	rows := t.Rows
+	if e.Header {
+		for c, col := range InferSchema(t).Columns {
+			switch col.Type {
+			case TypeInteger, TypeFloat, TypeCurrency, TypePercent:
+				numeric[c] = true
+			}
+		}
+
+		w.WriteString("<thead>\n")
+		writeHTMLRow(w, rows[0], "th", numeric)
+		w.WriteString("</thead>\n")
+		rows = rows[1:]
+	}
+
+	w.WriteString("<tbody>\n")
+	for _, row := range rows {
+		writeHTMLRow(w, row, "td", numeric)
+	}
+	w.WriteString("</tbody>\n</table>\n")
+}
+
+func writeHTMLRow(w *bufio.Writer, row []string, tag string, numeric map[int]bool) {
+	w.WriteString("<tr>")
+	for c, cell := range row {
+		w.WriteString("<" + tag)
+		if numeric[c] {
+			w.WriteString(` class="num"`)
+		}
+		w.WriteString(">" + html.EscapeString(cell) + "</" + tag + ">")
+	}
+	w.WriteString("</tr>\n")
+}
@@ -0,0 +1,71 @@
+package htmltable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLEncoder_Encode_RoundTrip(t *testing.T) {
+	tables := []Table{
+		{Index: 1, ID: "prices", Caption: "Prices", Rows: [][]string{{"Name", "Price"}, {"<a> & \"b\"", "1.5"}, {"c", "2"}}},
+		{Index: 2, Section: "Europe", Rows: [][]string{{"x"}}},
+	}
+
+	var buf bytes.Buffer
+	enc := NewHTMLEncoder()
+	enc.Title = "A & B"
+	if err := enc.Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	out := buf.String()
+
+	for _, s := range []string{
+		"<title>A &amp; B</title>",
+		`<table id="prices">`,
+		"<caption>Prices</caption>",
+		"<caption>Europe</caption>",
+		`<th class="num">Price</th>`,
+		`<td class="num">1.5</td>`,
+		"<td>&lt;a&gt; &amp; &#34;b&#34;</td>",
+	} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("output does not contain %q:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "<script>") {
+		t.Fatal("unexpected script in non-sortable output")
+	}
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "prices" || got[0].Caption != "Prices" {
+		t.Fatalf("unexpected tables: %+v", got)
+	}
+	assertRowsEqual(t, got[0].Rows, tables[0].Rows, "table 1")
+	assertRowsEqual(t, got[1].Rows, tables[1].Rows, "table 2")
+}
+
+func TestHTMLEncoder_Encode_SortableAndNoHeader(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a"}, {"b"}}}}
+
+	var buf bytes.Buffer
+	enc := &HTMLEncoder{Header: true, Sortable: true}
+	if err := enc.Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<script>") || !strings.Contains(buf.String(), "<thead>") {
+		t.Fatalf("expected sortable table with header:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	enc.Header = false
+	if err := enc.Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if strings.Contains(buf.String(), "<thead>") || strings.Contains(buf.String(), "<script>") {
+		t.Fatalf("unexpected header or script:\n%s", buf.String())
+	}
+}
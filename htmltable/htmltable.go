@@ -3,38 +3,142 @@
 package htmltable
 
 import (
-	"encoding/csv"
 	"errors"
 	"io"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
 
 type Table struct {
-	Index int
-	ID    string
-	Name  string
-	Rows  [][]string
+	Index   int
+	ID      string
+	Name    string
+	Caption string
+	Rows    [][]string
+
+	// Section is the text of the section row starting this part of the
+	// table, if the table was split with SplitSections.
+	Section string
+
+	// RowHeaders is set if the first cell of every row is a header cell and
+	// no row is all header cells, as in property/value tables, or if a cell
+	// has scope="row".
+	RowHeaders bool
+}
+
+// Parser extracts tables from HTML documents.
+// The zero value is ready to use.
+type Parser struct {
+	// Values lists where cell values are taken from, in order of preference.
+	Values []ValueRule
+
+	// KeepEmptyColumns and KeepEmptyRows disable the removal of columns
+	// and rows without any non-empty cell, keeping the layout stable.
+	KeepEmptyColumns bool
+	KeepEmptyRows    bool
+
+	// SkipHidden skips rows hidden with the hidden attribute or display:none.
+	SkipHidden bool
+
+	// KeepSpace keeps leading and trailing whitespace in cell values.
+	KeepSpace bool
+
+	// NoDirectoryListing disables the directory listing fallback
+	// for documents without tables.
+	NoDirectoryListing bool
+
+	// Sections sets how rows with a single cell spanning all columns,
+	// which start a group of rows, are handled.
+	Sections SectionMode
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// NewParser returns a Parser configured with opts.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithValueRules sets where cell values are taken from.
+func WithValueRules(rules ...ValueRule) Option {
+	return func(p *Parser) { p.Values = rules }
+}
+
+// WithEmptyColumns keeps or removes columns without non-empty cells.
+// They are removed by default.
+func WithEmptyColumns(keep bool) Option {
+	return func(p *Parser) { p.KeepEmptyColumns = keep }
+}
+
+// WithEmptyRows keeps or removes rows without non-empty cells.
+// They are removed by default.
+func WithEmptyRows(keep bool) Option {
+	return func(p *Parser) { p.KeepEmptyRows = keep }
+}
+
+// WithHiddenRows keeps or skips hidden rows. They are kept by default.
+func WithHiddenRows(keep bool) Option {
+	return func(p *Parser) { p.SkipHidden = !keep }
+}
+
+// WithTrimSpace enables or disables the removal of leading and trailing
+// whitespace from cell values. It is enabled by default.
+func WithTrimSpace(trim bool) Option {
+	return func(p *Parser) { p.KeepSpace = !trim }
+}
+
+// WithDirectoryListing enables or disables the parsing of directory
+// listings in documents without tables. It is enabled by default.
+func WithDirectoryListing(enabled bool) Option {
+	return func(p *Parser) { p.NoDirectoryListing = !enabled }
+}
+
+// WithSections sets how section rows are handled.
+// They are kept as ordinary rows by default.
+func WithSections(mode SectionMode) Option {
+	return func(p *Parser) { p.Sections = mode }
 }
 
 func Parse(r io.Reader) ([]Table, error) {
+	return NewParser().Parse(r)
+}
+
+func (p *Parser) Parse(r io.Reader) ([]Table, error) {
 	doc, err := html.Parse(r)
 	if err != nil {
 		return nil, err
 	}
 
 	var tables []Table
+	p.walkTables(doc, func(t Table) bool {
+		tables = append(tables, t)
+		return true
+	})
+	return tables, nil
+}
+
+// walkTables calls yield for every table in doc, in document order,
+// until yield returns false.
+// If doc has no tables, it yields the directory listing, if any.
+func (p *Parser) walkTables(doc *html.Node, yield func(Table) bool) {
+	found := false
 	index := 0
 
-	var walk func(*html.Node)
-	walk = func(n *html.Node) {
+	var walk func(*html.Node) bool
+	walk = func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.DataAtom == atom.Table {
 			index++
 
-			var id, name string
+			var id, name, caption string
 			for _, a := range n.Attr {
 				switch a.Key {
 				case "id":
@@ -43,30 +147,46 @@ func Parse(r io.Reader) ([]Table, error) {
 					name = a.Val
 				}
 			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && c.DataAtom == atom.Caption {
+					caption = strings.Join(strings.Fields(textContent(c)), " ")
+					break
+				}
+			}
 
-			rows := extractRows(n)
-			if len(rows) > 0 {
-				tables = append(tables, Table{
-					Index: index,
-					ID:    id,
-					Name:  name,
-					Rows:  rows,
-				})
+			sections, rowHeaders := p.extractRows(n)
+			for _, sec := range sections {
+				if len(sec.rows) == 0 {
+					continue
+				}
+				found = true
+				if !yield(Table{
+					Index:      index,
+					ID:         id,
+					Name:       name,
+					Caption:    caption,
+					Section:    sec.name,
+					Rows:       sec.rows,
+					RowHeaders: rowHeaders,
+				}) {
+					return false
+				}
 			}
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			walk(c)
+			if !walk(c) {
+				return false
+			}
 		}
+		return true
 	}
 	walk(doc)
 
-	if len(tables) == 0 {
+	if !found && !p.NoDirectoryListing {
 		if t, ok := parseDirectoryListing(doc); ok {
-			tables = append(tables, t)
+			yield(t)
 		}
 	}
-
-	return tables, nil
 }
 
 type Selector struct {
@@ -136,45 +256,158 @@ func SkipHeader(tables []Table) []Table {
 	return out
 }
 
+// Transpose returns the table with rows and columns swapped, so that the
+// row headers of a vertical table become the header row.
+func (t Table) Transpose() Table {
+	width := 0
+	for _, row := range t.Rows {
+		width = max(width, len(row))
+	}
+
+	rows := make([][]string, width)
+	for c := range rows {
+		rows[c] = make([]string, len(t.Rows))
+		for r, row := range t.Rows {
+			if c < len(row) {
+				rows[c][r] = row[c]
+			}
+		}
+	}
+
+	t.Rows = rows
+	t.RowHeaders = false
+	return t
+}
+
 type CSVEncoder struct {
 	Comma rune
+
+	// Quote is the quote character. The zero value means '"'.
+	Quote rune
+
+	// QuoteAll quotes every field, not only those that need it.
+	QuoteAll bool
+
+	// UseCRLF ends lines with \r\n instead of \n.
+	UseCRLF bool
+
+	// BOM writes a UTF-8 byte order mark first, as expected by Excel.
+	BOM bool
+
+	// Null, if not empty, is written unquoted for empty cells.
+	// Cells equal to Null are quoted to tell them apart.
+	Null string
+
+	// Separator selects what is written between tables.
+	// The zero value writes a blank line.
+	Separator SeparatorMode
+
+	// SeparatorText is the line written between tables
+	// with CustomSeparator.
+	SeparatorText string
 }
 
+// SeparatorMode selects what CSVEncoder writes between tables.
+type SeparatorMode int
+
+const (
+	// BlankSeparator writes an empty line between tables.
+	BlankSeparator SeparatorMode = iota
+
+	// NoSeparator writes nothing between tables.
+	NoSeparator
+
+	// CommentSeparator writes a line such as "# table 2 id=foo"
+	// before every table, including the first.
+	CommentSeparator
+
+	// CustomSeparator writes SeparatorText on its own line
+	// between tables.
+	CustomSeparator
+)
+
 func NewCSVEncoder() *CSVEncoder {
-	return &CSVEncoder{Comma: ','}
+	return &CSVEncoder{Comma: ',', Quote: '"'}
 }
 
 func (e *CSVEncoder) Encode(w io.Writer, tables []Table) error {
-	cw := csv.NewWriter(w)
-	cw.Comma = e.Comma
+	cw, err := newCSVWriter(w, e)
+	if err != nil {
+		return err
+	}
 
-	for _, t := range tables {
+	if e.BOM {
+		cw.w.WriteString("\uFEFF")
+	}
+
+	for i, t := range tables {
+		switch {
+		case e.Separator == CommentSeparator:
+			cw.w.WriteString(tableComment(t))
+			cw.newline()
+		case i == 0, e.Separator == NoSeparator:
+		case e.Separator == CustomSeparator:
+			cw.w.WriteString(e.SeparatorText)
+			cw.newline()
+		default:
+			cw.newline()
+		}
 		for _, row := range t.Rows {
-			if err := cw.Write(row); err != nil {
-				return err
-			}
+			cw.write(row)
 		}
-		_ = cw.Write([]string{})
 	}
 
-	cw.Flush()
-	return cw.Error()
+	return cw.flush()
+}
+
+// tableComment returns a comment line describing t, such as
+// "# table 2 id=prices".
+func tableComment(t Table) string {
+	s := "# table " + strconv.Itoa(t.Index)
+	for _, kv := range [][2]string{{"id", t.ID}, {"name", t.Name}, {"section", t.Section}} {
+		v := kv[1]
+		if strings.ContainsFunc(v, func(r rune) bool { return r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) }) {
+			v = strconv.Quote(v)
+		}
+		if v != "" {
+			s += " " + kv[0] + "=" + v
+		}
+	}
+	return s
 }
 
-func extractRows(table *html.Node) [][]string {
-	var rows [][]string
+// extractRows returns the rows of table, split by section rows if
+// p.Sections is SplitSections, and whether it has row headers.
+func (p *Parser) extractRows(table *html.Node) ([]section, bool) {
+	var raw []rawRow
+	firstTh, anyTd, scopeRow := true, false, false
+	headerRow := false // a row of header cells, as in cross-tabulations
 
 	var walk func(*html.Node)
 	walk = func(n *html.Node) {
+		if p.SkipHidden && isHidden(n) {
+			return
+		}
 		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
-			var row []string
+			var row rawRow
+			allTh := true
 			for c := n.FirstChild; c != nil; c = c.NextSibling {
 				if c.Type == html.ElementNode && (c.DataAtom == atom.Td || c.DataAtom == atom.Th) {
-					row = append(row, strings.TrimSpace(textContent(c)))
+					if len(row.cells) == 0 && c.DataAtom != atom.Th {
+						firstTh = false
+					}
+					if c.DataAtom == atom.Td {
+						anyTd, allTh = true, false
+					} else if scope, _ := attrValue(c, "scope"); strings.EqualFold(scope, "row") {
+						scopeRow = true
+					}
+					row.cells = append(row.cells, cellValue(c, p.Values, p.KeepSpace))
+					row.span += colspan(c)
 				}
 			}
-			if len(row) > 0 {
-				rows = append(rows, row)
+			if len(row.cells) > 0 {
+				raw = append(raw, row)
+				headerRow = headerRow || allTh
 			}
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
@@ -183,10 +416,7 @@ func extractRows(table *html.Node) [][]string {
 	}
 	walk(table)
 
-	rows = trimEmptyColumns(rows)
-	rows = dropEmptyRows(rows)
-	normalize(rows)
-	return rows
+	return p.sections(raw), scopeRow || len(raw) > 0 && firstTh && anyTd && !headerRow
 }
 
 func trimEmptyColumns(rows [][]string) [][]string {
@@ -264,6 +494,26 @@ func normalize(rows [][]string) {
 	}
 }
 
+func isHidden(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if _, ok := attrValue(n, "hidden"); ok {
+		return true
+	}
+	style, _ := attrValue(n, "style")
+	for decl := range strings.SplitSeq(style, ";") {
+		prop, val, ok := strings.Cut(decl, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(prop), "display") {
+			val = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(val), "!important"))
+			if strings.EqualFold(val, "none") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func textContent(n *html.Node) string {
 	var b strings.Builder
 
@@ -3,6 +3,7 @@ package htmltable
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -132,6 +133,177 @@ func TestParse_FallsBackToPseudoOnlyWhenNoRealTables(t *testing.T) {
 	assertRowsEqual(t, tables[0].Rows, [][]string{{"H"}, {"v"}}, "Rows")
 }
 
+func TestParser_KeepEmptyColumnsAndRows(t *testing.T) {
+	src := `
+<table>
+  <tr><th></th><th>Name</th><th>Size</th></tr>
+  <tr><td></td><td></td><td></td></tr>
+  <tr><td></td><td>file1</td></tr>
+</table>`
+
+	tests := []struct {
+		parser Parser
+		want   [][]string
+	}{
+		{
+			Parser{KeepEmptyColumns: true},
+			[][]string{{"", "Name", "Size"}, {"", "file1", ""}},
+		},
+		{
+			Parser{KeepEmptyRows: true},
+			[][]string{{"Name", "Size"}, {"", ""}, {"file1", ""}},
+		},
+		{
+			Parser{KeepEmptyColumns: true, KeepEmptyRows: true},
+			[][]string{{"", "Name", "Size"}, {"", "", ""}, {"", "file1", ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		tables, err := tt.parser.Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("Parse error: %v", err)
+		}
+		if len(tables) != 1 {
+			t.Fatalf("expected 1 table, got %d", len(tables))
+		}
+		assertRowsEqual(t, tables[0].Rows, tt.want, fmt.Sprintf("%+v", tt.parser))
+	}
+}
+
+func TestParser_SkipHidden(t *testing.T) {
+	src := `
+<table>
+  <tr><th>Name</th></tr>
+  <tr hidden><td>hidden attr</td></tr>
+  <tr style="color: red; DISPLAY : none !important"><td>display none</td></tr>
+  <tbody style="display:none"><tr><td>hidden group</td></tr></tbody>
+  <tr style="display: block"><td>visible</td></tr>
+</table>`
+
+	p := Parser{SkipHidden: true}
+	tables, err := p.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"Name"}, {"visible"}}, "Rows")
+
+	tables, err = Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables[0].Rows) != 5 {
+		t.Fatalf("expected hidden rows by default, got %#v", tables[0].Rows)
+	}
+}
+
+func TestNewParser_Options(t *testing.T) {
+	rules := []ValueRule{{Attr: "title"}}
+	p := NewParser(
+		WithValueRules(rules...),
+		WithEmptyColumns(true),
+		WithEmptyRows(true),
+		WithHiddenRows(false),
+		WithTrimSpace(false),
+		WithDirectoryListing(false),
+	)
+
+	if len(p.Values) != 1 || p.Values[0] != rules[0] || !p.KeepEmptyColumns || !p.KeepEmptyRows ||
+		!p.SkipHidden || !p.KeepSpace || !p.NoDirectoryListing {
+		t.Fatalf("unexpected parser: %+v", p)
+	}
+
+	p = NewParser()
+	if p.Values != nil || p.KeepEmptyColumns || p.KeepEmptyRows || p.SkipHidden || p.KeepSpace || p.NoDirectoryListing {
+		t.Fatalf("expected zero parser by default, got %+v", p)
+	}
+}
+
+func TestParser_KeepSpace(t *testing.T) {
+	src := `<table><tr><td> a </td><td title=" t ">x</td></tr></table>`
+
+	p := NewParser(WithTrimSpace(false), WithValueRules(ValueRule{Tag: "td", Attr: "title"}))
+	tables, err := p.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{" a ", " t "}}, "Rows")
+}
+
+func TestParser_NoDirectoryListing(t *testing.T) {
+	src := `<pre><a href="?C=N">Name</a><hr><a href="x">x</a> 2025-01-01 00:00 1K</pre>`
+
+	tables, err := NewParser(WithDirectoryListing(false)).Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 0 {
+		t.Fatalf("expected no tables, got %+v", tables)
+	}
+}
+
+func TestParse_RowHeaders(t *testing.T) {
+	html := `
+<table id="kv">
+  <tr><th>Weight</th><td>1 kg</td></tr>
+  <tr><th>Color</th><td>Red</td></tr>
+</table>
+<table id="scope">
+  <tr><td></td><th>2024</th><th>2025</th></tr>
+  <tr><th scope="row">Sales</th><td>1</td><td>2</td></tr>
+</table>
+<table id="plain">
+  <tr><th>Name</th><th>Size</th></tr>
+  <tr><td>a</td><td>1</td></tr>
+</table>
+<table id="headers-only">
+  <tr><th>Name</th><th>Size</th></tr>
+</table>
+<table id="matrix">
+  <tr><th></th><th>2024</th><th>2025</th></tr>
+  <tr><th>Sales</th><td>1</td><td>2</td></tr>
+  <tr><th>Costs</th><td>3</td><td>4</td></tr>
+</table>`
+
+	tables, err := Parse(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	want := map[string]bool{"kv": true, "scope": true, "plain": false, "headers-only": false, "matrix": false}
+	for _, tab := range tables {
+		if tab.RowHeaders != want[tab.ID] {
+			t.Fatalf("table %q: RowHeaders = %v, want %v", tab.ID, tab.RowHeaders, want[tab.ID])
+		}
+	}
+}
+
+func TestTable_Transpose(t *testing.T) {
+	tab := Table{Index: 1, RowHeaders: true, Rows: [][]string{
+		{"Weight", "1 kg", "2 kg"},
+		{"Color", "Red"},
+	}}
+
+	got := tab.Transpose()
+	if got.Index != 1 || got.RowHeaders {
+		t.Fatalf("unexpected metadata: %+v", got)
+	}
+	assertRowsEqual(t, got.Rows, [][]string{
+		{"Weight", "Color"},
+		{"1 kg", "Red"},
+		{"2 kg", ""},
+	}, "Transpose")
+
+	assertRowsEqual(t, got.Transpose().Rows, [][]string{
+		{"Weight", "1 kg", "2 kg"},
+		{"Color", "Red", ""},
+	}, "round trip")
+
+	if rows := (Table{}).Transpose().Rows; len(rows) != 0 {
+		t.Fatalf("expected no rows, got %v", rows)
+	}
+}
+
 func TestParse_ErrorFromReader(t *testing.T) {
 	r := &errReader{err: errors.New("boom")}
 	_, err := Parse(r)
@@ -264,8 +436,8 @@ func TestCSVEncoder_Encode_DefaultDelimiterAndBlankLineBetweenTables(t *testing.
 		t.Fatalf("Encode error: %v", err)
 	}
 
-	// Note: csv.Writer uses \n line endings.
-	want := "a,b\nc,d\n\n1\n2\n\n"
+	// Tables are separated by a blank line, without one after the last
+	want := "a,b\nc,d\n\n1\n2\n"
 	if buf.String() != want {
 		t.Fatalf("unexpected CSV output:\n%q\nwant:\n%q", buf.String(), want)
 	}
@@ -283,12 +455,43 @@ func TestCSVEncoder_Encode_CustomDelimiter(t *testing.T) {
 		t.Fatalf("Encode error: %v", err)
 	}
 
-	want := "a;b\n\n"
+	want := "a;b\n"
 	if buf.String() != want {
 		t.Fatalf("unexpected CSV output: %q want %q", buf.String(), want)
 	}
 }
 
+func TestCSVEncoder_Encode_Separators(t *testing.T) {
+	tables := []Table{
+		{Index: 1, ID: "foo", Rows: [][]string{{"a"}}},
+		{Index: 3, Name: "two words", Section: "Europe", Rows: [][]string{{"b"}}},
+	}
+
+	tests := []struct {
+		sep  SeparatorMode
+		text string
+		want string
+	}{
+		{BlankSeparator, "", "a\n\nb\n"},
+		{NoSeparator, "", "a\nb\n"},
+		{CommentSeparator, "", "# table 1 id=foo\na\n# table 3 name=\"two words\" section=Europe\nb\n"},
+		{CustomSeparator, "---", "a\n---\nb\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		enc := NewCSVEncoder()
+		enc.Separator = tt.sep
+		enc.SeparatorText = tt.text
+		if err := enc.Encode(&buf, tables); err != nil {
+			t.Fatalf("Encode error: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("separator %d: got %q, want %q", tt.sep, buf.String(), tt.want)
+		}
+	}
+}
+
 type errWriter struct{}
 
 func (errWriter) Write(p []byte) (int, error) {
@@ -0,0 +1,67 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"io"
+	"iter"
+
+	"golang.org/x/net/html"
+)
+
+// ParseSeq is like Parse but returns an iterator over the tables.
+func ParseSeq(r io.Reader) iter.Seq2[Table, error] {
+	return NewParser().ParseSeq(r)
+}
+
+// ParseSeq returns an iterator that yields tables as they are found in the
+// document read from r. Tables after the one where the loop stops are not
+// extracted. A read or parse error is yielded once with an empty Table.
+func (p *Parser) ParseSeq(r io.Reader) iter.Seq2[Table, error] {
+	return func(yield func(Table, error) bool) {
+		doc, err := html.Parse(r)
+		if err != nil {
+			yield(Table{}, err)
+			return
+		}
+		p.walkTables(doc, func(t Table) bool {
+			return yield(t, nil)
+		})
+	}
+}
+
+// All returns an iterator over the row indexes and rows of the table,
+// including the header.
+func (t Table) All() iter.Seq2[int, []string] {
+	return func(yield func(int, []string) bool) {
+		for i, row := range t.Rows {
+			if !yield(i, row) {
+				return
+			}
+		}
+	}
+}
+
+// RecordsSeq returns an iterator over the rows after the header as maps
+// keyed by column name, like Records.
+func (t Table) RecordsSeq() iter.Seq[map[string]string] {
+	return func(yield func(map[string]string) bool) {
+		if len(t.Rows) == 0 {
+			return
+		}
+		keys := uniqueNames(t.Rows[0])
+		for _, row := range t.Rows[1:] {
+			rec := make(map[string]string, len(keys))
+			for i, k := range keys {
+				if i < len(row) {
+					rec[k] = row[i]
+				} else {
+					rec[k] = ""
+				}
+			}
+			if !yield(rec) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package htmltable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSeq_YieldsTablesInOrder(t *testing.T) {
+	src := `
+<table id="a"><tr><td>1</td></tr></table>
+<table><tr><td></td></tr></table>
+<table id="c"><tr><td>3</td></tr></table>`
+
+	var ids []string
+	var indexes []int
+	for tab, err := range ParseSeq(strings.NewReader(src)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, tab.ID)
+		indexes = append(indexes, tab.Index)
+	}
+
+	assertSliceEqual(t, ids, []string{"a", "c"}, "ids")
+	if len(indexes) != 2 || indexes[0] != 1 || indexes[1] != 3 {
+		t.Fatalf("unexpected indexes: %v", indexes)
+	}
+}
+
+func TestParseSeq_StopsEarly(t *testing.T) {
+	src := `<table id="a"><tr><td>1</td></tr></table><table id="b"><tr><td>2</td></tr></table>`
+
+	n := 0
+	for range ParseSeq(strings.NewReader(src)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 iteration, got %d", n)
+	}
+}
+
+func TestParseSeq_DirectoryListingFallback(t *testing.T) {
+	src := `<pre><a href="?C=N">Name</a><hr><a href="x">x</a> 2025-01-01 00:00 1K</pre>`
+
+	var tables []Table
+	for tab, err := range ParseSeq(strings.NewReader(src)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tables = append(tables, tab)
+	}
+	if len(tables) != 1 || tables[0].Name != "directory" {
+		t.Fatalf("expected directory listing, got %+v", tables)
+	}
+}
+
+func TestParseSeq_Error(t *testing.T) {
+	boom := errors.New("boom")
+	n := 0
+	for _, err := range ParseSeq(&errReader{err: boom}) {
+		n++
+		if !errors.Is(err, boom) {
+			t.Fatalf("expected boom, got %v", err)
+		}
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 iteration, got %d", n)
+	}
+}
+
+func TestTable_All(t *testing.T) {
+	tab := Table{Rows: [][]string{{"h"}, {"a"}, {"b"}}}
+
+	var got []string
+	for i, row := range tab.All() {
+		if row[0] != tab.Rows[i][0] {
+			t.Fatalf("row %d mismatch", i)
+		}
+		got = append(got, row[0])
+		if i == 1 {
+			break
+		}
+	}
+	assertSliceEqual(t, got, []string{"h", "a"}, "All")
+}
+
+func TestTable_RecordsSeq(t *testing.T) {
+	tab := Table{Rows: [][]string{{"A", "B"}, {"1"}, {"3", "4"}}}
+
+	var got []map[string]string
+	for rec := range tab.RecordsSeq() {
+		got = append(got, rec)
+	}
+	if len(got) != 2 || got[0]["A"] != "1" || got[0]["B"] != "" || got[1]["B"] != "4" {
+		t.Fatalf("unexpected records: %#v", got)
+	}
+
+	for range (Table{}).RecordsSeq() {
+		t.Fatal("expected no records for empty table")
+	}
+}
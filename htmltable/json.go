@@ -0,0 +1,106 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONEncoder writes tables as a JSON array with one object per table,
+// holding its metadata and its rows as objects keyed by column name.
+// Column names are made unique as in Records and keep their order.
+type JSONEncoder struct {
+	// Infer converts values with the types given by InferSchema and
+	// writes empty cells as null. Otherwise all values are strings.
+	Infer bool
+}
+
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{}
+}
+
+func (e *JSONEncoder) Encode(w io.Writer, tables []Table) error {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+	for i, t := range tables {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := e.encodeTable(&buf, t); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
+
+func (e *JSONEncoder) encodeTable(buf *bytes.Buffer, t Table) error {
+	fmt.Fprintf(buf, `{"index":%d`, t.Index)
+	for _, kv := range tableMetadata(t) {
+		buf.WriteByte(',')
+		writeJSON(buf, kv[0])
+		buf.WriteByte(':')
+		writeJSON(buf, kv[1])
+	}
+	buf.WriteString(`,"rows":[`)
+
+	if len(t.Rows) > 1 {
+		keys := uniqueNames(t.Rows[0])
+		var schema Schema
+		if e.Infer {
+			schema = InferSchema(t)
+		}
+
+		for r, row := range t.Rows[1:] {
+			if r > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('{')
+			for i, k := range keys {
+				var v any = ""
+				if i < len(row) {
+					v = row[i]
+				}
+				if e.Infer {
+					var err error
+					if v, err = schema.Columns[i].Value(v.(string)); err != nil {
+						return fmt.Errorf("table %d: %w", t.Index, err)
+					}
+				}
+
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				writeJSON(buf, k)
+				buf.WriteByte(':')
+				if err := writeJSON(buf, v); err != nil {
+					return fmt.Errorf("table %d: column %q: %w", t.Index, k, err)
+				}
+			}
+			buf.WriteByte('}')
+		}
+	}
+
+	buf.WriteString("]}")
+	return nil
+}
+
+func writeJSON(buf *bytes.Buffer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
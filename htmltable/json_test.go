@@ -0,0 +1,77 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONEncoder_Encode(t *testing.T) {
+	tables := []Table{
+		{Index: 1, ID: "prices", Rows: [][]string{
+			{"Name", "Price", "Stock", "Name"},
+			{"apple", "1,234.5", "yes", "x"},
+			{"pear", "", "no", "y"},
+		}},
+		{Index: 2, Section: "Europe", Rows: [][]string{{"A"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONEncoder().Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	want := `[
+  {
+    "index": 1,
+    "id": "prices",
+    "rows": [
+      {
+        "Name": "apple",
+        "Price": "1,234.5",
+        "Stock": "yes",
+        "Name_2": "x"
+      },
+      {
+        "Name": "pear",
+        "Price": "",
+        "Stock": "no",
+        "Name_2": "y"
+      }
+    ]
+  },
+  {
+    "index": 2,
+    "section": "Europe",
+    "rows": []
+  }
+]
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := (&JSONEncoder{Infer: true}).Encode(&buf, tables[:1]); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	var got []struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	rows := got[0].Rows
+	if rows[0]["Price"] != 1234.5 || rows[0]["Stock"] != true || rows[1]["Price"] != nil || rows[1]["Stock"] != false {
+		t.Fatalf("unexpected typed rows: %v", rows)
+	}
+}
+
+func TestJSONEncoder_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONEncoder().Encode(&buf, nil); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
@@ -0,0 +1,78 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Merge combines tables into one whose header is the union of their header
+// rows, in order of first appearance. Columns are matched by name as in
+// Records, and cells of columns missing from a table are left empty.
+// If source is not empty, a leading column with that name holds the ID,
+// Name or Index of the table each row comes from. If a column already has
+// that name, the source column gets a suffix as in Records.
+// The merged table takes its metadata from the first table.
+func Merge(tables []Table, source string) Table {
+	var merged Table
+	var header []string
+	for i, t := range tables {
+		if i == 0 {
+			merged = Table{Index: t.Index, ID: t.ID, Name: t.Name, Caption: t.Caption, Section: t.Section}
+		}
+		for _, name := range uniqueNames(t.Header()) {
+			if !slices.Contains(header, name) {
+				header = append(header, name)
+			}
+		}
+	}
+
+	offset := 0
+	if source != "" {
+		offset = 1
+		names := uniqueNames(append(slices.Clone(header), source))
+		merged.Rows = append(merged.Rows, append([]string{names[len(names)-1]}, header...))
+	} else {
+		merged.Rows = append(merged.Rows, header)
+	}
+
+	for _, t := range tables {
+		if len(t.Rows) == 0 {
+			continue
+		}
+
+		pos := make([]int, len(t.Rows[0]))
+		for i, name := range uniqueNames(t.Rows[0]) {
+			pos[i] = offset + slices.Index(header, name)
+		}
+
+		var label string
+		switch {
+		case t.ID != "":
+			label = t.ID
+		case t.Name != "":
+			label = t.Name
+		default:
+			label = strconv.Itoa(t.Index)
+		}
+
+		for _, row := range t.Rows[1:] {
+			r := make([]string, offset+len(header))
+			if source != "" {
+				r[0] = label
+			}
+			for i, v := range row {
+				if i < len(pos) {
+					r[pos[i]] = v
+				}
+			}
+			merged.Rows = append(merged.Rows, r)
+		}
+	}
+
+	if len(header) == 0 {
+		merged.Rows = nil
+	}
+	return merged
+}
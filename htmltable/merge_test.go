@@ -0,0 +1,66 @@
+package htmltable
+
+import "testing"
+
+func TestMerge_UnionsByHeaderName(t *testing.T) {
+	tables := []Table{
+		{Index: 1, ID: "page1", Rows: [][]string{{"Name", "Price"}, {"a", "1"}, {"b", "2"}}},
+		{Index: 2, Rows: [][]string{{"Price", "Stock"}, {"3", "yes"}}},
+		{Index: 3, Name: "page3", Rows: [][]string{{"Name"}, {"d"}}},
+	}
+
+	got := Merge(tables, "")
+	if got.Index != 1 || got.ID != "page1" {
+		t.Fatalf("unexpected metadata: %+v", got)
+	}
+	assertRowsEqual(t, got.Rows, [][]string{
+		{"Name", "Price", "Stock"},
+		{"a", "1", ""},
+		{"b", "2", ""},
+		{"", "3", "yes"},
+		{"d", "", ""},
+	}, "Merge")
+
+	got = Merge(tables, "source")
+	assertRowsEqual(t, got.Rows, [][]string{
+		{"source", "Name", "Price", "Stock"},
+		{"page1", "a", "1", ""},
+		{"page1", "b", "2", ""},
+		{"2", "", "3", "yes"},
+		{"page3", "d", "", ""},
+	}, "Merge with source")
+}
+
+func TestMerge_DuplicateAndEmptyNames(t *testing.T) {
+	tables := []Table{
+		{Index: 1, Rows: [][]string{{"A", "A", ""}, {"1", "2", "3"}}},
+		{Index: 2, Rows: [][]string{{"A", "", "A"}, {"4", "5", "6"}}},
+	}
+
+	got := Merge(tables, "")
+	assertRowsEqual(t, got.Rows, [][]string{
+		{"A", "A_2", "column_3", "column_2"},
+		{"1", "2", "3", ""},
+		{"4", "6", "", "5"},
+	}, "Merge")
+}
+
+func TestMerge_NoTables(t *testing.T) {
+	if got := Merge(nil, "source"); len(got.Rows) != 0 {
+		t.Fatalf("expected no rows, got %v", got.Rows)
+	}
+}
+
+func TestMerge_SourceNameClash(t *testing.T) {
+	tables := []Table{
+		{Index: 1, Rows: [][]string{{"src", "a"}, {"x", "1"}}},
+		{Index: 2, Rows: [][]string{{"src_2"}, {"y"}}},
+	}
+
+	got := Merge(tables, "src")
+	assertRowsEqual(t, got.Rows, [][]string{
+		{"src_3", "src", "a", "src_2"},
+		{"1", "x", "1", ""},
+		{"2", "", "", "y"},
+	}, "Merge")
+}
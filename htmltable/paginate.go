@@ -0,0 +1,362 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Paginator extracts tables from a page and the pages that follow it.
+type Paginator struct {
+	// Parser extracts the tables of every page. If nil, a default Parser is used.
+	Parser *Parser
+
+	// Client fetches the pages.
+	// If nil, a client with a timeout of DefaultTimeout is used.
+	Client *http.Client
+
+	// Next selects the link to the next page.
+	// The zero value selects links with rel="next".
+	Next LinkSelector
+
+	// MaxPages limits the number of pages fetched. Zero means no limit.
+	MaxPages int
+}
+
+// DefaultTimeout limits the time taken by every request of a Paginator
+// without a Client.
+const DefaultTimeout = 30 * time.Second
+
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+func NewPaginator(p *Parser) *Paginator {
+	return &Paginator{Parser: p}
+}
+
+// Pages fetches the page at rawURL and every page found by following
+// next links, yielding the tables of each page.
+// It stops at the last page, at MaxPages, or when a page links back to
+// a page already fetched.
+func (pg *Paginator) Pages(rawURL string) iter.Seq2[[]Table, error] {
+	return func(yield func([]Table, error) bool) {
+		p := pg.Parser
+		if p == nil {
+			p = NewParser()
+		}
+
+		seen := make(map[string]bool)
+		for page := 0; rawURL != "" && (pg.MaxPages <= 0 || page < pg.MaxPages); page++ {
+			seen[rawURL] = true
+
+			docs, base, err := pg.fetch(rawURL)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			// A redirect to a page already fetched also ends the loop
+			if final := base.String(); final != rawURL {
+				if seen[final] {
+					return
+				}
+				seen[final] = true
+			}
+
+			var tables []Table
+			for _, doc := range docs {
+				var ts []Table
+				p.walkTables(doc, func(t Table) bool {
+					ts = append(ts, t)
+					return true
+				})
+				tables = appendNumbered(tables, ts)
+			}
+			if !yield(tables, nil) {
+				return
+			}
+
+			rawURL = ""
+			for _, doc := range docs {
+				next, ok := pg.Next.find(doc)
+				if !ok {
+					continue
+				}
+				if u, err := base.Parse(next); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+					u.Fragment = ""
+					if !seen[u.String()] {
+						rawURL = u.String()
+					}
+				}
+				break
+			}
+		}
+	}
+}
+
+// Parse returns the tables of all pages.
+// Tables with the same index and section on different pages are combined
+// with Merge.
+func (pg *Paginator) Parse(rawURL string) ([]Table, error) {
+	type key struct {
+		index   int
+		section string
+	}
+	var order []key
+	groups := make(map[key][]Table)
+	for tables, err := range pg.Pages(rawURL) {
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tables {
+			k := key{t.Index, t.Section}
+			if _, ok := groups[k]; !ok {
+				order = append(order, k)
+			}
+			groups[k] = append(groups[k], t)
+		}
+	}
+
+	out := make([]Table, 0, len(order))
+	for _, k := range order {
+		if g := groups[k]; len(g) == 1 {
+			out = append(out, g[0])
+		} else {
+			out = append(out, Merge(g, ""))
+		}
+	}
+	return out, nil
+}
+
+// fetch returns the HTML documents of the page at rawURL, read as in
+// ParseArchive, and the URL of the page after redirects.
+func (pg *Paginator) fetch(rawURL string) ([]*html.Node, *url.URL, error) {
+	client := pg.Client
+	if client == nil {
+		client = defaultClient
+	}
+
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("%s: %s", rawURL, resp.Status)
+	}
+
+	var docs []*html.Node
+	err = readDocuments(resp.Body, resp.Header.Get("Content-Type"), func(r io.Reader) error {
+		doc, err := html.Parse(r)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	base := *resp.Request.URL
+	base.Fragment = ""
+	return docs, &base, nil
+}
+
+// LinkSelector matches elements with a subset of CSS selector syntax:
+// compound selectors made of a tag name, #id, .class, [attr] and
+// [attr=value], optionally combined with descendant combinators,
+// as in "ul.pager li.next a".
+type LinkSelector struct {
+	parts []compound
+}
+
+type compound struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrMatch
+}
+
+type attrMatch struct {
+	key, val string
+	hasVal   bool
+}
+
+// ParseLinkSelector parses a link selector. An empty string gives the
+// zero LinkSelector, which matches links with rel="next".
+func ParseLinkSelector(s string) (LinkSelector, error) {
+	var sel LinkSelector
+	for field := range strings.FieldsSeq(s) {
+		c, err := parseCompound(field)
+		if err != nil {
+			return LinkSelector{}, fmt.Errorf("invalid link selector %q: %w", s, err)
+		}
+		sel.parts = append(sel.parts, c)
+	}
+	return sel, nil
+}
+
+func parseCompound(s string) (compound, error) {
+	var c compound
+
+	i := strings.IndexAny(s, "#.[")
+	if i < 0 {
+		i = len(s)
+	}
+	c.tag = strings.ToLower(s[:i])
+	s = s[i:]
+
+	for s != "" {
+		kind := s[0]
+		s = s[1:]
+		if kind == '[' {
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return c, errors.New("missing ]")
+			}
+			key, val, hasVal := strings.Cut(s[:end], "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if key == "" {
+				return c, errors.New("empty attribute name")
+			}
+			val = strings.Trim(strings.TrimSpace(val), `"'`)
+			c.attrs = append(c.attrs, attrMatch{key: key, val: val, hasVal: hasVal})
+			s = s[end+1:]
+			continue
+		}
+
+		end := strings.IndexAny(s, "#.[")
+		if end < 0 {
+			end = len(s)
+		}
+		name := s[:end]
+		if name == "" {
+			return c, fmt.Errorf("empty name after %q", kind)
+		}
+		if kind == '#' {
+			c.id = name
+		} else {
+			c.classes = append(c.classes, name)
+		}
+		s = s[end:]
+	}
+
+	return c, nil
+}
+
+func (c compound) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode || c.tag != "" && c.tag != "*" && n.Data != c.tag {
+		return false
+	}
+	if c.id != "" {
+		if id, _ := attrValue(n, "id"); id != c.id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		class, _ := attrValue(n, "class")
+		fields := strings.Fields(class)
+		for _, name := range c.classes {
+			if !slices.Contains(fields, name) {
+				return false
+			}
+		}
+	}
+	for _, a := range c.attrs {
+		v, ok := attrValue(n, a.key)
+		if !ok || a.hasVal && v != a.val {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether n matches the last compound selector and its
+// ancestors match the preceding ones.
+func (s LinkSelector) matches(n *html.Node) bool {
+	last := len(s.parts) - 1
+	if !s.parts[last].matches(n) {
+		return false
+	}
+	i := last - 1
+	for p := n.Parent; p != nil && i >= 0; p = p.Parent {
+		if s.parts[i].matches(p) {
+			i--
+		}
+	}
+	return i < 0
+}
+
+// find returns the href of the first matching element in doc, or of the
+// first link inside it.
+func (s LinkSelector) find(doc *html.Node) (string, bool) {
+	var href string
+	var found bool
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if found {
+			return
+		}
+		if n.Type == html.ElementNode {
+			if len(s.parts) == 0 {
+				if isNextLink(n) {
+					href, found = attrValue(n, "href")
+					return
+				}
+			} else if s.matches(n) {
+				href, found = linkHref(n)
+				if found {
+					return
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return href, found && strings.TrimSpace(href) != ""
+}
+
+func isNextLink(n *html.Node) bool {
+	if n.DataAtom != atom.A && n.DataAtom != atom.Link {
+		return false
+	}
+	if _, ok := attrValue(n, "href"); !ok {
+		return false
+	}
+	rel, _ := attrValue(n, "rel")
+	for token := range strings.FieldsSeq(rel) {
+		if strings.EqualFold(token, "next") {
+			return true
+		}
+	}
+	return false
+}
+
+// linkHref returns the href of n, or of the first descendant with one.
+func linkHref(n *html.Node) (string, bool) {
+	if href, ok := attrValue(n, "href"); ok {
+		return href, true
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			if href, ok := linkHref(c); ok {
+				return href, true
+			}
+		}
+	}
+	return "", false
+}
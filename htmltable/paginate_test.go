@@ -0,0 +1,200 @@
+package htmltable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pagedServer serves /page/1 to /page/n, each with a layout table and a
+// table of rows linked to the next page by link.
+func pagedServer(t *testing.T, n int, link func(page int) string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		if _, err := fmt.Sscanf(r.URL.Path, "/page/%d", &page); err != nil || page < 1 || page > n {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `<html><body>
+<table id="layout"><tr><td>menu</td></tr></table>
+<table id="data"><tr><th>Page</th><th>Row</th></tr>
+<tr><td>%d</td><td>a</td></tr><tr><td>%d</td><td>b</td></tr></table>
+%s
+</body></html>`, page, page, link(page))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func relNext(n int) func(int) string {
+	return func(page int) string {
+		if page == n {
+			return ""
+		}
+		return fmt.Sprintf(`<a rel="prev nofollow" href="/page/%d">Prev</a> <a rel="next" href="%d#top">Next</a>`, page-1, page+1)
+	}
+}
+
+func TestPaginator_FollowsRelNext(t *testing.T) {
+	srv := pagedServer(t, 3, relNext(3))
+
+	tables, err := NewPaginator(nil).Parse(srv.URL + "/page/1")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+	if tables[1].ID != "data" {
+		t.Fatalf("expected table %q, got %q", "data", tables[1].ID)
+	}
+	assertRowsEqual(t, tables[1].Rows, [][]string{
+		{"Page", "Row"},
+		{"1", "a"}, {"1", "b"},
+		{"2", "a"}, {"2", "b"},
+		{"3", "a"}, {"3", "b"},
+	}, "data")
+}
+
+func TestPaginator_MaxPages(t *testing.T) {
+	srv := pagedServer(t, 5, relNext(5))
+
+	pg := NewPaginator(NewParser())
+	pg.MaxPages = 2
+
+	pages := 0
+	for tables, err := range pg.Pages(srv.URL + "/page/1") {
+		if err != nil {
+			t.Fatalf("Pages error: %v", err)
+		}
+		if len(tables) != 2 {
+			t.Fatalf("expected 2 tables, got %d", len(tables))
+		}
+		pages++
+	}
+	if pages != 2 {
+		t.Fatalf("expected 2 pages, got %d", pages)
+	}
+}
+
+func TestPaginator_LinkSelector(t *testing.T) {
+	srv := pagedServer(t, 3, func(page int) string {
+		// The first link points back to page 1, which must not loop
+		return fmt.Sprintf(`<ul class="pager"><li><a href="/page/1">First</a></li>
+<li class="next disabled"><span>x</span><a href="/page/%d">Next</a></li></ul>`, page%3+1)
+	})
+
+	next, err := ParseLinkSelector("ul.pager li.next")
+	if err != nil {
+		t.Fatalf("ParseLinkSelector error: %v", err)
+	}
+	pg := &Paginator{Next: next}
+
+	tables, err := pg.Parse(srv.URL + "/page/1")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got := len(tables[1].Rows); got != 7 {
+		t.Fatalf("expected 7 rows, got %d", got)
+	}
+}
+
+func TestPaginator_SplitSections(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next := `<a rel="next" href="/2">Next</a>`
+		if r.URL.Path == "/2" {
+			next = ""
+		}
+		fmt.Fprintf(w, `<table><tr><th>City</th><th>Page</th></tr>
+<tr><td colspan="2">Europe</td></tr><tr><td>Paris</td><td>%[1]s</td></tr>
+<tr><td colspan="2">Asia</td></tr><tr><td>Tokyo</td><td>%[1]s</td></tr>
+</table>%[2]s`, r.URL.Path, next)
+	}))
+	t.Cleanup(srv.Close)
+
+	pg := NewPaginator(NewParser(WithSections(SplitSections)))
+	tables, err := pg.Parse(srv.URL + "/1")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 2 || tables[0].Section != "Europe" || tables[1].Section != "Asia" {
+		t.Fatalf("expected Europe and Asia tables, got %+v", tables)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"City", "Page"}, {"Paris", "/1"}, {"Paris", "/2"}}, "Europe")
+	assertRowsEqual(t, tables[1].Rows, [][]string{{"City", "Page"}, {"Tokyo", "/1"}, {"Tokyo", "/2"}}, "Asia")
+}
+
+func TestPaginator_CompressedPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1" {
+			fmt.Fprint(w, `<table><tr><th>City</th></tr><tr><td>Paris</td></tr></table><a rel="next" href="/2">Next</a>`)
+			return
+		}
+		// A gzip file served as such, in ISO-8859-1
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte("<table><tr><th>City</th></tr><tr><td>M\xfcnchen</td></tr></table>"))
+		zw.Close()
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	tables, err := NewPaginator(nil).Parse(srv.URL + "/1")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"City"}, {"Paris"}, {"München"}}, "merged")
+}
+
+func TestPaginator_RedirectLoop(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2" {
+			http.Redirect(w, r, "/1", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, `<table><tr><th>Page</th></tr><tr><td>1</td></tr></table><a rel="next" href="/2">Next</a>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	var pages int
+	for _, err := range NewPaginator(nil).Pages(srv.URL + "/1") {
+		if err != nil {
+			t.Fatalf("Pages error: %v", err)
+		}
+		pages++
+	}
+	if pages != 1 {
+		t.Fatalf("expected 1 page, got %d", pages)
+	}
+}
+
+func TestPaginator_Error(t *testing.T) {
+	srv := pagedServer(t, 1, func(int) string { return `<a rel="next" href="/page/2">Next</a>` })
+
+	_, err := NewPaginator(nil).Parse(srv.URL + "/page/1")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+}
+
+func TestParseLinkSelector(t *testing.T) {
+	for _, s := range []string{"a[rel=next]", `a[rel="next"]`, "#next", ".pager a.next", "*[data-page]", ""} {
+		if _, err := ParseLinkSelector(s); err != nil {
+			t.Fatalf("ParseLinkSelector(%q) error: %v", s, err)
+		}
+	}
+	for _, s := range []string{"a[rel", "a.", "a#", "a[=x]"} {
+		if _, err := ParseLinkSelector(s); err == nil {
+			t.Fatalf("ParseLinkSelector(%q): expected error, got nil", s)
+		}
+	}
+}
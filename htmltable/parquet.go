@@ -0,0 +1,361 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// ParquetEncoder writes a single table as an uncompressed Parquet file.
+// The first row is the header and column types are inferred with InferSchema.
+// Empty cells are written as nulls.
+type ParquetEncoder struct{}
+
+func NewParquetEncoder() *ParquetEncoder {
+	return &ParquetEncoder{}
+}
+
+// Parquet physical types, encodings and other enums from parquet.thrift
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetOptional = 1
+
+	parquetUTF8            = 0
+	parquetTimestampMicros = 10
+
+	parquetDataPage = 0
+)
+
+type parquetChunk struct {
+	physical  int32
+	numValues int64
+	offset    int64
+	size      int64
+}
+
+func (e *ParquetEncoder) Encode(w io.Writer, tables []Table) error {
+	if len(tables) != 1 {
+		return fmt.Errorf("parquet output requires exactly one table, got %d", len(tables))
+	}
+
+	t := tables[0]
+	if len(t.Rows) == 0 {
+		return fmt.Errorf("table %d has no header", t.Index)
+	}
+
+	schema := InferSchema(t)
+	names := uniqueNames(t.Rows[0])
+	rows := t.Rows[1:]
+
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, "PAR1"); err != nil {
+		return err
+	}
+
+	chunks := make([]parquetChunk, len(schema.Columns))
+	for i, col := range schema.Columns {
+		page, err := parquetPage(col, i, rows)
+		if err != nil {
+			return err
+		}
+
+		var h thriftWriter
+		h.i32(1, parquetDataPage)
+		h.i32(2, int32(len(page)))
+		h.i32(3, int32(len(page)))
+		h.beginStruct(5)
+		h.i32(1, int32(len(rows)))
+		h.i32(2, parquetPlain)
+		h.i32(3, parquetRLE)
+		h.i32(4, parquetRLE)
+		h.endStruct()
+		h.stop()
+
+		chunks[i] = parquetChunk{
+			physical:  parquetPhysicalType(col.Type),
+			numValues: int64(len(rows)),
+			offset:    cw.n,
+			size:      int64(h.buf.Len() + len(page)),
+		}
+		if _, err := cw.Write(h.buf.Bytes()); err != nil {
+			return err
+		}
+		if _, err := cw.Write(page); err != nil {
+			return err
+		}
+	}
+
+	var m thriftWriter
+	m.i32(1, 1)
+
+	m.beginList(2, thriftStruct, len(schema.Columns)+1)
+	m.beginElem()
+	m.binary(4, "schema")
+	m.i32(5, int32(len(schema.Columns)))
+	m.endElem()
+	for i, col := range schema.Columns {
+		m.beginElem()
+		m.i32(1, parquetPhysicalType(col.Type))
+		m.i32(3, parquetOptional)
+		m.binary(4, names[i])
+		switch col.Type {
+		case TypeString:
+			m.i32(6, parquetUTF8)
+			m.beginStruct(10)
+			m.beginStruct(1) // STRING
+			m.endStruct()
+			m.endStruct()
+		case TypeTime:
+			m.i32(6, parquetTimestampMicros)
+			m.beginStruct(10)
+			m.beginStruct(8) // TIMESTAMP
+			m.boolean(1, true)
+			m.beginStruct(2)
+			m.beginStruct(2) // MICROS
+			m.endStruct()
+			m.endStruct()
+			m.endStruct()
+			m.endStruct()
+		}
+		m.endElem()
+	}
+
+	m.i64(3, int64(len(rows)))
+
+	if len(rows) == 0 {
+		m.beginList(4, thriftStruct, 0)
+	} else {
+		var total int64
+		m.beginList(4, thriftStruct, 1)
+		m.beginElem()
+		m.beginList(1, thriftStruct, len(chunks))
+		for i, c := range chunks {
+			m.beginElem()
+			m.i64(2, c.offset)
+			m.beginStruct(3)
+			m.i32(1, c.physical)
+			m.beginList(2, thriftI32, 2)
+			m.listI32(parquetPlain)
+			m.listI32(parquetRLE)
+			m.beginList(3, thriftBinary, 1)
+			m.listBinary(names[i])
+			m.i32(4, 0) // UNCOMPRESSED
+			m.i64(5, c.numValues)
+			m.i64(6, c.size)
+			m.i64(7, c.size)
+			m.i64(9, c.offset)
+			m.endStruct()
+			m.endElem()
+			total += c.size
+		}
+		m.i64(2, total)
+		m.i64(3, int64(len(rows)))
+		m.endElem()
+	}
+
+	m.binary(6, "html2csv")
+	m.stop()
+
+	if _, err := cw.Write(m.buf.Bytes()); err != nil {
+		return err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, uint32(m.buf.Len())); err != nil {
+		return err
+	}
+	_, err := io.WriteString(cw, "PAR1")
+	return err
+}
+
+func parquetPhysicalType(t ColumnType) int32 {
+	switch t {
+	case TypeInteger, TypeTime:
+		return parquetInt64
+	case TypeFloat, TypeCurrency, TypePercent:
+		return parquetDouble
+	case TypeBoolean:
+		return parquetBoolean
+	}
+	return parquetByteArray
+}
+
+// parquetPage returns a v1 data page with the definition levels
+// and the PLAIN encoded non-null values of column i.
+func parquetPage(col Column, i int, rows [][]string) ([]byte, error) {
+	levels := make([]bool, len(rows))
+	var values bytes.Buffer
+	var bools []bool
+
+	for r, row := range rows {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		v, err := col.Value(cell)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		levels[r] = true
+
+		switch v := v.(type) {
+		case string:
+			_ = binary.Write(&values, binary.LittleEndian, uint32(len(v)))
+			values.WriteString(v)
+		case int64:
+			_ = binary.Write(&values, binary.LittleEndian, v)
+		case float64:
+			_ = binary.Write(&values, binary.LittleEndian, math.Float64bits(v))
+		case bool:
+			bools = append(bools, v)
+		case time.Time:
+			_ = binary.Write(&values, binary.LittleEndian, v.UnixMicro())
+		}
+	}
+
+	if col.Type == TypeBoolean {
+		values.Write(packBits(bools))
+	}
+
+	// Definition levels use the RLE/bit-packing hybrid encoding with a
+	// bit width of 1, written as a single bit-packed run.
+	var def bytes.Buffer
+	def.Write(binary.AppendUvarint(nil, uint64((len(levels)+7)/8)<<1|1))
+	def.Write(packBits(levels))
+
+	var page bytes.Buffer
+	_ = binary.Write(&page, binary.LittleEndian, uint32(def.Len()))
+	page.Write(def.Bytes())
+	page.Write(values.Bytes())
+	return page.Bytes(), nil
+}
+
+func packBits(bits []bool) []byte {
+	out := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Thrift compact protocol types
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the subset of the Thrift compact protocol
+// needed to write Parquet page headers and file metadata.
+type thriftWriter struct {
+	buf   bytes.Buffer
+	last  int16
+	outer []int16
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.buf.Write(binary.AppendVarint(nil, int64(id)))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.buf.Write(binary.AppendVarint(nil, int64(v)))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.buf.Write(binary.AppendVarint(nil, v))
+}
+
+func (t *thriftWriter) boolean(id int16, v bool) {
+	if v {
+		t.field(id, thriftTrue)
+	} else {
+		t.field(id, thriftFalse)
+	}
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.listBinary(s)
+}
+
+func (t *thriftWriter) beginStruct(id int16) {
+	t.field(id, thriftStruct)
+	t.beginElem()
+}
+
+func (t *thriftWriter) endStruct() {
+	t.endElem()
+}
+
+func (t *thriftWriter) beginList(id int16, elem byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elem)
+	} else {
+		t.buf.WriteByte(0xf0 | elem)
+		t.buf.Write(binary.AppendUvarint(nil, uint64(n)))
+	}
+}
+
+// beginElem and endElem delimit a struct that is a list element.
+func (t *thriftWriter) beginElem() {
+	t.outer = append(t.outer, t.last)
+	t.last = 0
+}
+
+func (t *thriftWriter) endElem() {
+	t.stop()
+	t.last = t.outer[len(t.outer)-1]
+	t.outer = t.outer[:len(t.outer)-1]
+}
+
+func (t *thriftWriter) listI32(v int32) {
+	t.buf.Write(binary.AppendVarint(nil, int64(v)))
+}
+
+func (t *thriftWriter) listBinary(s string) {
+	t.buf.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) stop() {
+	t.buf.WriteByte(0)
+}
@@ -0,0 +1,96 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestParquetEncoder_Encode_FileLayout(t *testing.T) {
+	tables := []Table{{Index: 1, Rows: [][]string{
+		{"Name", "Size", "Enabled", "Date"},
+		{"a", "10", "yes", "2025-01-02"},
+		{"b", "", "no", "2025-01-03"},
+	}}}
+
+	var buf bytes.Buffer
+	if err := NewParquetEncoder().Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatalf("missing PAR1 magic: %q", data)
+	}
+
+	n := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if n <= 0 || n > len(data)-12 {
+		t.Fatalf("invalid footer length %d for file of %d bytes", n, len(data))
+	}
+	footer := data[len(data)-8-n : len(data)-8]
+	for _, name := range []string{"schema", "Name", "Size", "Enabled", "Date", "html2csv"} {
+		if !bytes.Contains(footer, []byte(name)) {
+			t.Fatalf("footer does not contain %q", name)
+		}
+	}
+
+	// String values are PLAIN encoded with a 4-byte length prefix.
+	if !bytes.Contains(data, []byte("\x01\x00\x00\x00a\x01\x00\x00\x00b")) {
+		t.Fatalf("string column values not found: %q", data)
+	}
+}
+
+func TestParquetEncoder_Encode_RequiresOneTable(t *testing.T) {
+	enc := NewParquetEncoder()
+
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, nil); err == nil {
+		t.Fatal("expected error for no tables, got nil")
+	}
+
+	tables := []Table{{Rows: [][]string{{"a"}}}, {Rows: [][]string{{"b"}}}}
+	if err := enc.Encode(&buf, tables); err == nil {
+		t.Fatal("expected error for two tables, got nil")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output on error, got %q", buf.Bytes())
+	}
+}
+
+func TestParquetEncoder_Encode_PropagatesWriterError(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a"}, {"b"}}}}
+	if err := NewParquetEncoder().Encode(errWriter{}, tables); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestThriftWriter_CompactEncoding(t *testing.T) {
+	var w thriftWriter
+	w.i32(1, -1)
+	w.i64(20, 300)
+	w.beginStruct(21)
+	w.boolean(1, true)
+	w.endStruct()
+	w.beginList(22, thriftBinary, 1)
+	w.listBinary("ab")
+	w.stop()
+
+	want := []byte{
+		0x15, 0x01, // field 1 i32, zigzag(-1)
+		0x06, 0x28, 0xd8, 0x04, // field 20 i64 with long-form id, zigzag(300)
+		0x1c,       // field 21 struct
+		0x11, 0x00, // field 1 true, stop
+		0x19, 0x18, 0x02, 'a', 'b', // field 22 list<binary> of 1
+		0x00,
+	}
+	if !bytes.Equal(w.buf.Bytes(), want) {
+		t.Fatalf("got % x\nwant % x", w.buf.Bytes(), want)
+	}
+}
+
+func TestPackBits(t *testing.T) {
+	got := packBits([]bool{true, false, true, false, false, false, false, false, true})
+	if !bytes.Equal(got, []byte{0x05, 0x01}) {
+		t.Fatalf("got % x", got)
+	}
+}
@@ -0,0 +1,255 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header returns the first row of the table, or nil if it has no rows.
+func (t Table) Header() []string {
+	if len(t.Rows) == 0 {
+		return nil
+	}
+	return t.Rows[0]
+}
+
+// Records returns the rows after the header as maps keyed by column name.
+// Empty column names become "column_N" and duplicates get a "_2", "_3"...
+// suffix.
+func (t Table) Records() []map[string]string {
+	return slices.Collect(t.RecordsSeq())
+}
+
+// Column returns the values of the named column, excluding the header,
+// or nil if there is no such column. Names are matched as in Records.
+func (t Table) Column(name string) []string {
+	i := t.columnIndex(name)
+	if i < 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(t.Rows)-1)
+	for _, row := range t.Rows[1:] {
+		if i < len(row) {
+			out = append(out, row[i])
+		} else {
+			out = append(out, "")
+		}
+	}
+	return out
+}
+
+func (t Table) columnIndex(name string) int {
+	if len(t.Rows) == 0 {
+		return -1
+	}
+	for i, k := range uniqueNames(t.Rows[0]) {
+		if k == name {
+			return i
+		}
+	}
+	return -1
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Unmarshal stores the rows after the header in v, which must be a pointer
+// to a slice of structs or of pointers to structs.
+//
+// Struct fields are matched to columns by the name given in the
+// `htmltable:"Name"` tag, or by the field name, case-insensitively.
+// Fields tagged with `htmltable:"-"`, unexported fields and fields without
+// a matching column are left unset, as are fields for empty cells.
+//
+// Supported field types are strings, booleans, integers, floating point
+// numbers, time.Time, types implementing encoding.TextUnmarshaler and
+// pointers to those. Numbers may use thousands separators, and numbers in
+// currency and percentage columns may include the symbol. Times are parsed
+// with the layout inferred for the column.
+func (t Table) Unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return errors.New("htmltable: Unmarshal requires a non-nil pointer to a slice")
+	}
+	slice := rv.Elem()
+
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("htmltable: cannot unmarshal into slice of %s", elemType)
+	}
+
+	if len(t.Rows) == 0 {
+		slice.SetLen(0)
+		return nil
+	}
+
+	schema := InferSchema(t)
+	keys := uniqueNames(t.Rows[0])
+
+	// Map struct fields to columns
+	type field struct {
+		index  []int
+		column int
+	}
+	var fields []field
+	for _, f := range reflect.VisibleFields(structType) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("htmltable"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		column := -1
+		for i, k := range keys {
+			if k == name {
+				column = i
+				break
+			}
+			if column < 0 && strings.EqualFold(k, name) {
+				column = i
+			}
+		}
+		if column >= 0 {
+			fields = append(fields, field{index: f.Index, column: column})
+		}
+	}
+
+	out := reflect.MakeSlice(slice.Type(), 0, len(t.Rows)-1)
+	for r, row := range t.Rows[1:] {
+		elem := reflect.New(structType).Elem()
+		for _, f := range fields {
+			if f.column >= len(row) {
+				continue
+			}
+			cell := strings.TrimSpace(row[f.column])
+			if cell == "" {
+				continue
+			}
+			fv, err := elem.FieldByIndexErr(f.index)
+			if err != nil {
+				continue // field promoted through a nil embedded pointer
+			}
+			if err := setField(fv, cell, schema.Columns[f.column]); err != nil {
+				return fmt.Errorf("htmltable: row %d, column %q: %w", r+1, keys[f.column], err)
+			}
+		}
+		if elemType.Kind() == reflect.Pointer {
+			elem = elem.Addr()
+		}
+		out = reflect.Append(out, elem)
+	}
+
+	slice.Set(out)
+	return nil
+}
+
+func setField(fv reflect.Value, s string, col Column) error {
+	if fv.Kind() == reflect.Pointer {
+		p := reflect.New(fv.Type().Elem())
+		if err := setField(p.Elem(), s, col); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+
+	if fv.Type() != timeType && reflect.PointerTo(fv.Type()).Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	decimal := col.Decimal
+	if decimal == 0 {
+		decimal = '.'
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+		return nil
+	case reflect.Bool:
+		if b, ok := parseBool(s); ok {
+			fv.SetBool(b)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", s)
+		}
+		fv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := parseInteger(numericPart(s, col), decimal)
+		if !ok || fv.OverflowInt(n) {
+			return fmt.Errorf("invalid integer %q", s)
+		}
+		fv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := parseInteger(numericPart(s, col), decimal)
+		if !ok || n < 0 || fv.OverflowUint(uint64(n)) {
+			return fmt.Errorf("invalid unsigned integer %q", s)
+		}
+		fv.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := parseNumber(numericPart(s, col), decimal)
+		if !ok || fv.OverflowFloat(f) {
+			return fmt.Errorf("invalid number %q", s)
+		}
+		fv.SetFloat(f)
+		return nil
+	}
+
+	if fv.Type() == timeType {
+		layouts := timeLayouts
+		if col.Type == TypeTime {
+			layouts = []string{col.Layout}
+		}
+		for _, layout := range layouts {
+			if tm, err := time.Parse(layout, s); err == nil {
+				fv.Set(reflect.ValueOf(tm))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time %q", s)
+	}
+
+	return fmt.Errorf("unsupported field type %s", fv.Type())
+}
+
+// numericPart strips the currency symbol or percent sign from s
+// if col is a currency or percentage column.
+func numericPart(s string, col Column) string {
+	switch col.Type {
+	case TypeCurrency:
+		if _, num, ok := splitCurrency(s); ok {
+			return num
+		}
+	case TypePercent:
+		if num, ok := strings.CutSuffix(s, "%"); ok {
+			return strings.TrimSpace(num)
+		}
+	}
+	return s
+}
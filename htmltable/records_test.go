@@ -0,0 +1,143 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func recordsTestTable() Table {
+	return Table{Index: 1, Rows: [][]string{
+		{"Name", "Size", "", "Name", "Modified", "Price", "Share", "Flag"},
+		{"a", "1,024", "x", "dup", "25-Aug-2025 20:08", "$1.50", "10%", "yes"},
+		{"b", "", "y", "", "26-Aug-2025 08:00", "$2", "5.5 %", "no"},
+	}}
+}
+
+func TestTable_HeaderRecordsColumn(t *testing.T) {
+	tab := recordsTestTable()
+
+	assertSliceEqual(t, tab.Header(), tab.Rows[0], "Header")
+	if (Table{}).Header() != nil {
+		t.Fatal("expected nil header for empty table")
+	}
+
+	recs := tab.Records()
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(recs))
+	}
+	if recs[0]["Name"] != "a" || recs[0]["Name_2"] != "dup" || recs[0]["column_3"] != "x" || recs[1]["Size"] != "" {
+		t.Fatalf("unexpected records: %#v", recs)
+	}
+
+	assertSliceEqual(t, tab.Column("Size"), []string{"1,024", ""}, "Column(Size)")
+	assertSliceEqual(t, tab.Column("Name_2"), []string{"dup", ""}, "Column(Name_2)")
+	if c := tab.Column("missing"); c != nil {
+		t.Fatalf("expected nil for missing column, got %#v", c)
+	}
+}
+
+type upperString string
+
+func (u *upperString) UnmarshalText(b []byte) error {
+	*u = upperString(strings.ToUpper(string(b)))
+	return nil
+}
+
+type recordsTestRow struct {
+	Name       string
+	Size       *int64
+	Other      upperString `htmltable:"Name_2"`
+	Modified   time.Time
+	Price      float64
+	Share      float32
+	Flag       bool
+	Skipped    string `htmltable:"-"`
+	Missing    int    `htmltable:"nope"`
+	unexported string
+}
+
+func TestTable_Unmarshal(t *testing.T) {
+	var rows []recordsTestRow
+	if err := recordsTestTable().Unmarshal(&rows); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	r := rows[0]
+	if r.Name != "a" || r.Size == nil || *r.Size != 1024 || r.Other != "DUP" || r.Price != 1.5 || r.Share != 10 || !r.Flag {
+		t.Fatalf("unexpected row[0]: %+v", r)
+	}
+	if !r.Modified.Equal(time.Date(2025, 8, 25, 20, 8, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %v", r.Modified)
+	}
+	if r.Skipped != "" || r.Missing != 0 {
+		t.Fatalf("unexpected unmatched fields: %+v", r)
+	}
+
+	r = rows[1]
+	if r.Size != nil || r.Other != "" || r.Share != 5.5 || r.Flag {
+		t.Fatalf("unexpected row[1]: %+v", r)
+	}
+}
+
+func TestTable_Unmarshal_PointerElementsAndCaseInsensitiveNames(t *testing.T) {
+	tab := Table{Rows: [][]string{{"NAME", "count"}, {"x", "3"}}}
+
+	var rows []*struct {
+		Name  string
+		Count uint8
+	}
+	if err := tab.Unmarshal(&rows); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "x" || rows[0].Count != 3 {
+		t.Fatalf("unexpected rows: %+v", rows[0])
+	}
+}
+
+func TestTable_Unmarshal_ZeroPaddedNumbers(t *testing.T) {
+	tab := Table{Rows: [][]string{{"ID", "Code"}, {"007", "BAW123"}}}
+
+	var rows []struct {
+		ID   int
+		Code string
+	}
+	if err := tab.Unmarshal(&rows); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != 7 || rows[0].Code != "BAW123" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestTable_Unmarshal_Errors(t *testing.T) {
+	tab := Table{Rows: [][]string{{"N"}, {"300"}, {"-1"}}}
+
+	var ints []struct{ N int8 }
+	if err := tab.Unmarshal(&ints); err == nil {
+		t.Fatal("expected overflow error, got nil")
+	}
+
+	var uints []struct{ N uint }
+	if err := tab.Unmarshal(&uints); err == nil {
+		t.Fatal("expected error for negative unsigned, got nil")
+	}
+
+	var bad []struct{ N []int }
+	if err := tab.Unmarshal(&bad); err == nil {
+		t.Fatal("expected unsupported type error, got nil")
+	}
+
+	var notSlice struct{ N int }
+	if err := tab.Unmarshal(&notSlice); err == nil {
+		t.Fatal("expected error for non-slice, got nil")
+	}
+
+	var strs []string
+	if err := tab.Unmarshal(&strs); err == nil {
+		t.Fatal("expected error for slice of non-structs, got nil")
+	}
+}
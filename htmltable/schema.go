@@ -0,0 +1,470 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type ColumnType int
+
+const (
+	TypeString ColumnType = iota
+	TypeInteger
+	TypeFloat
+	TypeBoolean
+	TypeTime
+	TypeCurrency
+	TypePercent
+)
+
+var columnTypeNames = [...]string{
+	TypeString:   "string",
+	TypeInteger:  "integer",
+	TypeFloat:    "float",
+	TypeBoolean:  "boolean",
+	TypeTime:     "time",
+	TypeCurrency: "currency",
+	TypePercent:  "percent",
+}
+
+func (t ColumnType) String() string {
+	if t >= 0 && int(t) < len(columnTypeNames) {
+		return columnTypeNames[t]
+	}
+	return "ColumnType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Column describes the type of a table column and how its cells are parsed.
+type Column struct {
+	Name string
+	Type ColumnType
+
+	// Decimal is the decimal separator of numeric columns ('.' or ',').
+	Decimal rune
+
+	// Symbol is the currency symbol or code of Currency columns,
+	// empty if it differs between cells.
+	Symbol string
+
+	// Layout is the time.Parse layout of Time columns.
+	Layout string
+}
+
+type Schema struct {
+	Columns []Column
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"02-Jan-2006 15:04:05",
+	"02-Jan-2006 15:04",
+	"02-Jan-2006",
+	"2006-Jan-02 15:04:05",
+	"2006-Jan-02 15:04",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	"Jan 2, 2006 15:04:05",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// InferSchema infers the type of every column of t.
+// The first row is taken as the header and gives the column names.
+// Empty cells are ignored; a column is only typed if all its other cells
+// parse as the same type, otherwise it is a String column.
+func InferSchema(t Table) Schema {
+	if len(t.Rows) == 0 {
+		return Schema{}
+	}
+
+	header := t.Rows[0]
+	s := Schema{Columns: make([]Column, len(header))}
+
+	for i, name := range header {
+		var cells []string
+		for _, row := range t.Rows[1:] {
+			if i < len(row) {
+				if v := strings.TrimSpace(row[i]); v != "" {
+					cells = append(cells, v)
+				}
+			}
+		}
+		s.Columns[i] = inferColumn(cells)
+		s.Columns[i].Name = name
+	}
+
+	return s
+}
+
+// Values converts row with the column types of the schema.
+// Empty cells are returned as nil.
+func (s Schema) Values(row []string) ([]any, error) {
+	out := make([]any, len(row))
+	for i, v := range row {
+		if i >= len(s.Columns) {
+			out[i] = v
+			continue
+		}
+		val, err := s.Columns[i].Value(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = val
+	}
+	return out, nil
+}
+
+// Value converts a cell of the column to string, int64, float64, bool
+// or time.Time, according to the column type.
+// Currency and Percent values are the float64 amount without the symbol.
+// Empty cells are returned as nil.
+func (c Column) Value(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var v any
+	ok := false
+
+	switch c.Type {
+	case TypeString:
+		return s, nil
+	case TypeInteger:
+		v, ok = parseInteger(s, c.Decimal)
+	case TypeFloat:
+		v, ok = parseNumber(s, c.Decimal)
+	case TypeBoolean:
+		v, ok = parseBool(s)
+	case TypeTime:
+		t, err := time.Parse(c.Layout, s)
+		v, ok = t, err == nil
+	case TypeCurrency:
+		_, num, isCurrency := splitCurrency(s)
+		if isCurrency {
+			v, ok = parseNumber(num, c.Decimal)
+		}
+	case TypePercent:
+		if num, isPercent := strings.CutSuffix(s, "%"); isPercent {
+			v, ok = parseNumber(strings.TrimSpace(num), c.Decimal)
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("column %q: invalid %s value %q", c.Name, c.Type, s)
+	}
+	return v, nil
+}
+
+func inferColumn(cells []string) Column {
+	if len(cells) == 0 {
+		return Column{Type: TypeString}
+	}
+
+	if all(cells, func(s string) bool { _, ok := parseBool(s); return ok }) {
+		return Column{Type: TypeBoolean}
+	}
+
+	// Zero-padded values such as "01234" are codes, not numbers
+	if !slices.ContainsFunc(cells, zeroPadded) {
+		for _, dec := range []rune{'.', ','} {
+			if all(cells, func(s string) bool { _, ok := parseInteger(s, dec); return ok }) {
+				return Column{Type: TypeInteger, Decimal: dec}
+			}
+			if all(cells, func(s string) bool { _, ok := parseNumber(s, dec); return ok }) {
+				return Column{Type: TypeFloat, Decimal: dec}
+			}
+		}
+	}
+
+	for _, dec := range []rune{'.', ','} {
+		if all(cells, func(s string) bool {
+			num, ok := strings.CutSuffix(s, "%")
+			if ok {
+				_, ok = parseNumber(strings.TrimSpace(num), dec)
+			}
+			return ok
+		}) {
+			return Column{Type: TypePercent, Decimal: dec}
+		}
+	}
+
+	for _, dec := range []rune{'.', ','} {
+		symbol := ""
+		if all(cells, func(s string) bool {
+			sym, num, ok := splitCurrency(s)
+			if ok && codeSeparated(s, sym) {
+				_, ok = parseNumber(num, dec)
+			} else {
+				ok = false
+			}
+			if ok && sym != symbol {
+				if symbol == "" {
+					symbol = sym
+				} else {
+					symbol = "\x00"
+				}
+			}
+			return ok
+		}) {
+			if symbol == "\x00" {
+				symbol = ""
+			}
+			return Column{Type: TypeCurrency, Decimal: dec, Symbol: symbol}
+		}
+	}
+
+	for _, layout := range timeLayouts {
+		if all(cells, func(s string) bool { _, err := time.Parse(layout, s); return err == nil }) {
+			return Column{Type: TypeTime, Layout: layout}
+		}
+	}
+
+	return Column{Type: TypeString}
+}
+
+// zeroPadded reports whether s starts with a zero followed by another digit.
+func zeroPadded(s string) bool {
+	s = strings.TrimLeft(s, "+-\u2212")
+	return len(s) > 1 && s[0] == '0' && s[1] >= '0' && s[1] <= '9'
+}
+
+// codeSeparated reports whether s has a currency symbol, or a currency code
+// separated from the amount by a space as in "EUR 5". Identifiers such as
+// "BAW123" are thus not taken for amounts.
+func codeSeparated(s, sym string) bool {
+	if hasCurrencySymbol(sym) {
+		return true
+	}
+	s = strings.TrimLeft(s, "+-\u2212 ")
+	if rest, ok := strings.CutPrefix(s, sym); ok {
+		r, _ := utf8.DecodeRuneInString(rest)
+		return unicode.IsSpace(r)
+	}
+	if rest, ok := strings.CutSuffix(s, sym); ok {
+		r, _ := utf8.DecodeLastRuneInString(rest)
+		return unicode.IsSpace(r)
+	}
+	return false
+}
+
+func all(cells []string, f func(string) bool) bool {
+	for _, s := range cells {
+		if !f(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "true", "yes":
+		return true, true
+	case "false", "no":
+		return false, true
+	}
+	return false, false
+}
+
+func parseInteger(s string, decimal rune) (int64, bool) {
+	digits, ok := normalizeNumber(s, decimal)
+	if !ok || strings.ContainsAny(digits, ".eE") {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	return n, err == nil
+}
+
+func parseNumber(s string, decimal rune) (float64, bool) {
+	digits, ok := normalizeNumber(s, decimal)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(digits, 64)
+	return f, err == nil
+}
+
+// normalizeNumber removes thousands separators from s and replaces the
+// decimal separator with a dot, so that it can be parsed by strconv.
+// Digit groups after the first must have exactly three digits.
+func normalizeNumber(s string, decimal rune) (string, bool) {
+	var b strings.Builder
+
+	rest := s
+	if r, n := utf8.DecodeRuneInString(rest); r == '-' || r == '+' || r == '\u2212' {
+		if r != '+' {
+			b.WriteByte('-')
+		}
+		rest = rest[n:]
+	}
+
+	rest, exp, hasExp := strings.Cut(strings.ToLower(rest), "e")
+	if hasExp && !isDigits(strings.TrimLeft(exp, "+-")) {
+		return "", false
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(rest, string(decimal))
+	if hasFrac && fracPart != "" && !isDigits(fracPart) {
+		return "", false
+	}
+
+	group := -1 // digits in current group, -1 before the first separator
+	count := 0
+	for _, r := range intPart {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			count++
+			if group >= 0 {
+				group++
+			}
+		case isGroupSeparator(r, decimal):
+			if count == 0 || count > 3 && group < 0 || group >= 0 && group != 3 {
+				return "", false
+			}
+			group = 0
+		default:
+			return "", false
+		}
+	}
+	if group >= 0 && group != 3 || count == 0 && fracPart == "" {
+		return "", false
+	}
+
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	if hasExp {
+		b.WriteByte('e')
+		b.WriteString(exp)
+	}
+
+	return b.String(), true
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+func isGroupSeparator(r, decimal rune) bool {
+	switch r {
+	case ' ', '\u00a0', '\u202f', '\'', '\u2019':
+		return true
+	case '.', ',':
+		return r != decimal
+	}
+	return false
+}
+
+// splitCurrency splits s into a currency symbol or code and an amount.
+// The symbol may precede or follow the amount, and the amount may have
+// a sign before or after the symbol.
+func splitCurrency(s string) (string, string, bool) {
+	sign := ""
+	if r, n := utf8.DecodeRuneInString(s); r == '-' || r == '+' || r == '\u2212' {
+		sign = s[:n]
+		s = strings.TrimSpace(s[n:])
+	}
+
+	isSymbol := func(r rune) bool { return unicode.IsLetter(r) || unicode.Is(unicode.Sc, r) }
+
+	prefixLen := strings.IndexFunc(s, func(r rune) bool { return !isSymbol(r) })
+	if prefixLen < 0 {
+		return "", "", false
+	}
+	prefix := s[:prefixLen]
+	rest := strings.TrimRightFunc(s[prefixLen:], isSymbol)
+	suffix := s[prefixLen+len(rest):]
+	rest = strings.TrimSpace(rest)
+
+	if prefix != "" && suffix != "" || !validCurrency(prefix+suffix) {
+		return "", "", false
+	}
+	if sign == "" && prefix != "" {
+		if r, n := utf8.DecodeRuneInString(rest); r == '-' || r == '+' || r == '\u2212' {
+			sign = rest[:n]
+			rest = strings.TrimSpace(rest[n:])
+		}
+	}
+	if rest == "" {
+		return "", "", false
+	}
+
+	return prefix + suffix, sign + rest, true
+}
+
+func validCurrency(sym string) bool {
+	if hasCurrencySymbol(sym) {
+		return true
+	}
+	if len(sym) != 3 {
+		return false
+	}
+	for _, r := range sym {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasCurrencySymbol(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool { return unicode.Is(unicode.Sc, r) })
+}
+
+// uniqueNames returns header with empty names replaced by "column_N"
+// (1-based) and duplicates suffixed with "_2", "_3", and so on.
+func uniqueNames(header []string) []string {
+	names := make([]string, len(header))
+	seen := make(map[string]struct{}, len(header))
+
+	for i, h := range header {
+		name := strings.TrimSpace(h)
+		if name == "" {
+			name = "column_" + strconv.Itoa(i+1)
+		}
+		base := name
+		for n := 2; ; n++ {
+			if _, ok := seen[name]; !ok {
+				break
+			}
+			name = base + "_" + strconv.Itoa(n)
+		}
+		seen[name] = struct{}{}
+		names[i] = name
+	}
+
+	return names
+}
@@ -0,0 +1,200 @@
+package htmltable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInferSchema_DetectsColumnTypes(t *testing.T) {
+	table := Table{Rows: [][]string{
+		{"Name", "Count", "Ratio", "Enabled", "Modified", "Price", "Share", "EU"},
+		{"a", "1,234", "1.5", "yes", "25-Aug-2025 20:08", "$1,234.50", "12.5%", "1.234,5"},
+		{"b", "-7", "2", "No", "01-Sep-2025 08:00", "$-3", "100 %", "2,25"},
+		{"c", "", "1e3", "", "", "", "", ""},
+	}}
+
+	s := InferSchema(table)
+
+	want := []Column{
+		{Name: "Name", Type: TypeString},
+		{Name: "Count", Type: TypeInteger, Decimal: '.'},
+		{Name: "Ratio", Type: TypeFloat, Decimal: '.'},
+		{Name: "Enabled", Type: TypeBoolean},
+		{Name: "Modified", Type: TypeTime, Layout: "02-Jan-2006 15:04"},
+		{Name: "Price", Type: TypeCurrency, Decimal: '.', Symbol: "$"},
+		{Name: "Share", Type: TypePercent, Decimal: '.'},
+		{Name: "EU", Type: TypeFloat, Decimal: ','},
+	}
+	if len(s.Columns) != len(want) {
+		t.Fatalf("expected %d columns, got %d", len(want), len(s.Columns))
+	}
+	for i := range want {
+		if s.Columns[i] != want[i] {
+			t.Fatalf("column[%d]: got %+v want %+v", i, s.Columns[i], want[i])
+		}
+	}
+}
+
+func TestInferSchema_MixedValuesFallBackToString(t *testing.T) {
+	table := Table{Rows: [][]string{
+		{"Size", "Grouping", "Currency"},
+		{"3.3G", "12,34,5", "$5"},
+		{"112", "1", "5"},
+	}}
+
+	for i, c := range InferSchema(table).Columns {
+		if c.Type != TypeString {
+			t.Fatalf("column[%d]: expected string, got %s", i, c.Type)
+		}
+	}
+}
+
+func TestInferSchema_IdentifiersAreStrings(t *testing.T) {
+	table := Table{Rows: [][]string{
+		{"Flight", "Zip", "Fare"},
+		{"BAW123", "01234", "EUR 99"},
+		{"DLH400", "90210", "EUR 120"},
+	}}
+
+	s := InferSchema(table)
+	for i, want := range []ColumnType{TypeString, TypeString, TypeCurrency} {
+		if s.Columns[i].Type != want {
+			t.Fatalf("column %q: got %s want %s", s.Columns[i].Name, s.Columns[i].Type, want)
+		}
+	}
+}
+
+func TestCodeSeparated(t *testing.T) {
+	tests := map[string]bool{
+		"EUR 5":  true,
+		"5 EUR":  true,
+		"-USD 5": true,
+		"$5":     true,
+		"BAW123": false,
+		"123EUR": false,
+		"USD-5":  false,
+	}
+	for s, want := range tests {
+		sym, _, _ := splitCurrency(s)
+		if got := codeSeparated(s, sym); got != want {
+			t.Errorf("codeSeparated(%q, %q) = %v, want %v", s, sym, got, want)
+		}
+	}
+}
+
+func TestInferSchema_Empty(t *testing.T) {
+	if s := InferSchema(Table{}); len(s.Columns) != 0 {
+		t.Fatalf("expected no columns, got %+v", s)
+	}
+}
+
+func TestSchema_Values(t *testing.T) {
+	table := Table{Rows: [][]string{
+		{"Name", "Count", "Enabled", "Date", "Price", "Share"},
+		{"a", "1 234", "true", "2025-01-02", "1.234,50 EUR", "12,5%"},
+		{"b", "", "false", "2025-01-03", "-2 EUR", "7%"},
+	}}
+
+	s := InferSchema(table)
+
+	got, err := s.Values(table.Rows[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []any{"a", int64(1234), true, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), 1234.5, 12.5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("value[%d]: got %#v want %#v", i, got[i], want[i])
+		}
+	}
+
+	got, err = s.Values(table.Rows[2])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[1] != nil {
+		t.Fatalf("expected nil for empty cell, got %#v", got[1])
+	}
+	if got[4] != -2.0 {
+		t.Fatalf("expected -2, got %#v", got[4])
+	}
+
+	if _, err := s.Values([]string{"c", "x"}); err == nil {
+		t.Fatal("expected error for invalid integer, got nil")
+	}
+}
+
+func TestColumnType_String(t *testing.T) {
+	if s := TypeCurrency.String(); s != "currency" {
+		t.Fatalf("got %q", s)
+	}
+	if s := ColumnType(42).String(); s != "ColumnType(42)" {
+		t.Fatalf("got %q", s)
+	}
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		decimal rune
+		want    string
+		ok      bool
+	}{
+		{"1,234,567.89", '.', "1234567.89", true},
+		{"1.234.567,89", ',', "1234567.89", true},
+		{"1 234", ',', "1234", true},
+		{"1'234.5", '.', "1234.5", true},
+		{"−5", '.', "-5", true},
+		{"+.5", '.', ".5", true},
+		{"1.5e-3", '.', "1.5e-3", true},
+		{"1234,567", '.', "", false},
+		{"12,34", '.', "", false},
+		{"1,2345", '.', "", false},
+		{",123", '.', "", false},
+		{"1.", '.', "1.", true},
+		{".", '.', "", false},
+		{"-", '.', "", false},
+		{"1.2.3", '.', "", false},
+		{"0x10", '.', "", false},
+		{"1e3", '.', "1e3", true},
+		{"1e", '.', "", false},
+		{"e3", '.', "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := normalizeNumber(tt.in, tt.decimal)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("normalizeNumber(%q, %q) = %q, %v; want %q, %v", tt.in, tt.decimal, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSplitCurrency(t *testing.T) {
+	tests := []struct {
+		in, sym, num string
+		ok           bool
+	}{
+		{"$10", "$", "10", true},
+		{"-€5.5", "€", "-5.5", true},
+		{"£ -3", "£", "-3", true},
+		{"10 USD", "USD", "10", true},
+		{"US$ 1,000", "US$", "1,000", true},
+		{"10", "", "", false},
+		{"Foo 10", "", "", false},
+		{"$", "", "", false},
+		{"$10 USD", "", "", false},
+	}
+
+	for _, tt := range tests {
+		sym, num, ok := splitCurrency(tt.in)
+		if ok != tt.ok || sym != tt.sym || num != tt.num {
+			t.Fatalf("splitCurrency(%q) = %q, %q, %v; want %q, %q, %v", tt.in, sym, num, ok, tt.sym, tt.num, tt.ok)
+		}
+	}
+}
+
+func TestUniqueNames(t *testing.T) {
+	got := uniqueNames([]string{"Name", "", "Name", " Size ", "Name", "Name_2"})
+	want := []string{"Name", "column_2", "Name_2", "Size", "Name_3", "Name_2_2"}
+	assertSliceEqual(t, got, want, "uniqueNames")
+}
@@ -0,0 +1,181 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// SectionMode selects how section rows are handled.
+// A section row has a single non-empty cell spanning all the columns of
+// the table, as in a <th colspan="3">Europe</th> heading followed by the
+// rows of that group.
+type SectionMode int
+
+const (
+	// NoSections keeps section rows as ordinary rows.
+	NoSections SectionMode = iota
+
+	// SectionColumn removes section rows and adds a leading "Section"
+	// column holding the text of the section each row belongs to.
+	SectionColumn
+
+	// SplitSections splits the table into one Table per section, each
+	// starting with the rows before the first section, usually the header.
+	SplitSections
+)
+
+// SectionColumnName is the name of the column added by SectionColumn.
+const SectionColumnName = "Section"
+
+// ParseSectionMode parses "none", "column" or "split".
+func ParseSectionMode(s string) (SectionMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none":
+		return NoSections, nil
+	case "column":
+		return SectionColumn, nil
+	case "split":
+		return SplitSections, nil
+	}
+	return NoSections, fmt.Errorf("invalid section mode %q", s)
+}
+
+type rawRow struct {
+	cells []string
+	span  int // number of columns covered by the cells
+}
+
+type section struct {
+	name string
+	rows [][]string
+}
+
+func colspan(cell *html.Node) int {
+	v, _ := attrValue(cell, "colspan")
+	if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// sections removes empty columns and rows from raw and applies p.Sections.
+// The first section holds the rows before the first section row.
+func (p *Parser) sections(raw []rawRow) []section {
+	width := 0
+	for _, r := range raw {
+		width = max(width, r.span)
+	}
+
+	// Section number of each row, 0 before the first section row
+	var rows [][]string
+	var index []int
+	names := []string{""}
+	for _, r := range raw {
+		if p.Sections != NoSections && width > 1 && len(r.cells) == 1 && r.span >= width && strings.TrimSpace(r.cells[0]) != "" {
+			names = append(names, strings.TrimSpace(r.cells[0]))
+			continue
+		}
+		rows = append(rows, r.cells)
+		index = append(index, len(names)-1)
+	}
+
+	if !p.KeepEmptyColumns {
+		rows = trimEmptyColumns(rows)
+	}
+
+	parts := make([]section, len(names))
+	for i, row := range rows {
+		parts[index[i]].rows = append(parts[index[i]].rows, row)
+	}
+	for i := range parts {
+		parts[i].name = names[i]
+		if !p.KeepEmptyRows {
+			parts[i].rows = dropEmptyRows(parts[i].rows)
+		}
+	}
+
+	if len(parts) == 1 {
+		normalize(parts[0].rows)
+		return parts
+	}
+
+	header := parts[0].rows
+	switch p.Sections {
+	case SectionColumn:
+		var out [][]string
+		for i, row := range header {
+			name := ""
+			if i == 0 {
+				name = SectionColumnName
+			}
+			out = append(out, append([]string{name}, row...))
+		}
+		for _, part := range parts[1:] {
+			for _, row := range part.rows {
+				out = append(out, append([]string{part.name}, row...))
+			}
+		}
+		normalize(out)
+		return []section{{rows: out}}
+	default:
+		var out []section
+		for _, part := range parts[1:] {
+			if len(part.rows) == 0 {
+				continue
+			}
+			rows := slices.Concat(cloneRows(header), part.rows)
+			normalize(rows)
+			out = append(out, section{name: part.name, rows: rows})
+		}
+		if len(out) == 0 {
+			// Keep a table whose sections are all empty, as SectionColumn does
+			normalize(header)
+			return []section{{rows: header}}
+		}
+		return out
+	}
+}
+
+func cloneRows(rows [][]string) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = slices.Clone(row)
+	}
+	return out
+}
+
+// SplitBy splits the table into one Table per distinct value of the named
+// column, in order of first appearance, each starting with the header row.
+// Section holds the value. Columns are named as in Records.
+func (t Table) SplitBy(column string) ([]Table, error) {
+	col := t.columnIndex(column)
+	if col < 0 {
+		return nil, fmt.Errorf("no column %q", column)
+	}
+
+	var out []Table
+	index := make(map[string]int)
+	for _, row := range t.Rows[1:] {
+		var v string
+		if col < len(row) {
+			v = row[col]
+		}
+		i, ok := index[v]
+		if !ok {
+			i = len(out)
+			index[v] = i
+			part := t
+			part.Section = v
+			part.Rows = [][]string{slices.Clone(t.Rows[0])}
+			out = append(out, part)
+		}
+		out[i].Rows = append(out[i].Rows, row)
+	}
+	return out, nil
+}
@@ -0,0 +1,151 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+)
+
+const sectionedHTML = `
+<table id="pop">
+  <tr><th>Country</th><th>Capital</th></tr>
+  <tr><th colspan="2">Europe</th></tr>
+  <tr><td>France</td><td>Paris</td></tr>
+  <tr><td>Spain</td><td>Madrid</td></tr>
+  <tr><td colspan="2"></td></tr>
+  <tr><th colspan="2"> Asia </th></tr>
+  <tr><td>Japan</td><td>Tokyo</td></tr>
+</table>`
+
+func TestParser_Sections_None(t *testing.T) {
+	tables, err := Parse(strings.NewReader(sectionedHTML))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{
+		{"Country", "Capital"},
+		{"Europe", ""},
+		{"France", "Paris"},
+		{"Spain", "Madrid"},
+		{"Asia", ""},
+		{"Japan", "Tokyo"},
+	}, "rows")
+}
+
+func TestParser_Sections_Column(t *testing.T) {
+	tables, err := NewParser(WithSections(SectionColumn)).Parse(strings.NewReader(sectionedHTML))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{
+		{"Section", "Country", "Capital"},
+		{"Europe", "France", "Paris"},
+		{"Europe", "Spain", "Madrid"},
+		{"Asia", "Japan", "Tokyo"},
+	}, "rows")
+}
+
+func TestParser_Sections_Split(t *testing.T) {
+	tables, err := NewParser(WithSections(SplitSections)).Parse(strings.NewReader(sectionedHTML))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+	for _, tab := range tables {
+		if tab.Index != 1 || tab.ID != "pop" {
+			t.Fatalf("unexpected metadata: %+v", tab)
+		}
+	}
+	if tables[0].Section != "Europe" || tables[1].Section != "Asia" {
+		t.Fatalf("unexpected sections %q, %q", tables[0].Section, tables[1].Section)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{
+		{"Country", "Capital"},
+		{"France", "Paris"},
+		{"Spain", "Madrid"},
+	}, "Europe")
+	assertRowsEqual(t, tables[1].Rows, [][]string{
+		{"Country", "Capital"},
+		{"Japan", "Tokyo"},
+	}, "Asia")
+
+	// The header rows must not be shared
+	tables[0].Rows[0][0] = "x"
+	if tables[1].Rows[0][0] != "Country" {
+		t.Fatal("header rows are shared between sections")
+	}
+}
+
+func TestParser_Sections_SplitOnlyHeadings(t *testing.T) {
+	const doc = `<table>
+<tr><th>Country</th><th>Capital</th></tr>
+<tr><th colspan="2">Europe</th></tr>
+</table>`
+
+	tables, err := NewParser(WithSections(SplitSections)).Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Section != "" {
+		t.Fatalf("expected the unsplit table, got %+v", tables)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"Country", "Capital"}}, "rows")
+}
+
+func TestParser_Sections_SingleColumnTable(t *testing.T) {
+	html := `<table><tr><th colspan="1">A</th></tr><tr><td>b</td></tr></table>`
+	tables, err := NewParser(WithSections(SplitSections)).Parse(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Section != "" {
+		t.Fatalf("unexpected tables: %+v", tables)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"A"}, {"b"}}, "rows")
+}
+
+func TestParseSectionMode(t *testing.T) {
+	tests := map[string]SectionMode{"": NoSections, "none": NoSections, "Column": SectionColumn, "split": SplitSections}
+	for s, want := range tests {
+		got, err := ParseSectionMode(s)
+		if err != nil || got != want {
+			t.Fatalf("ParseSectionMode(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseSectionMode("rows"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestTable_SplitBy(t *testing.T) {
+	tab := Table{Index: 3, ID: "pop", Rows: [][]string{
+		{"Region", "Country"},
+		{"Europe", "France"},
+		{"Asia", "Japan"},
+		{"Europe", "Spain"},
+	}}
+
+	got, err := tab.SplitBy("Region")
+	if err != nil {
+		t.Fatalf("SplitBy error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(got))
+	}
+	if got[0].Section != "Europe" || got[1].Section != "Asia" || got[1].Index != 3 || got[1].ID != "pop" {
+		t.Fatalf("unexpected metadata: %+v, %+v", got[0], got[1])
+	}
+	assertRowsEqual(t, got[0].Rows, [][]string{{"Region", "Country"}, {"Europe", "France"}, {"Europe", "Spain"}}, "Europe")
+	assertRowsEqual(t, got[1].Rows, [][]string{{"Region", "Country"}, {"Asia", "Japan"}}, "Asia")
+
+	if _, err := tab.SplitBy("Missing"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
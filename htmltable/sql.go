@@ -0,0 +1,184 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLEncoder writes a CREATE TABLE statement followed by INSERT statements
+// for every table. Tables are named after their ID, Name or Index and the first
+// row of each table gives the column names.
+type SQLEncoder struct {
+	// Dialect selects identifier quoting, string escaping and column types:
+	// "postgres", "mysql" or "sqlite".
+	Dialect string
+
+	// Infer infers column types from the table contents and writes empty
+	// cells as NULL. Otherwise every column is TEXT.
+	Infer bool
+}
+
+func NewSQLEncoder() *SQLEncoder {
+	return &SQLEncoder{Dialect: "postgres"}
+}
+
+func (e *SQLEncoder) Encode(w io.Writer, tables []Table) error {
+	var quote rune
+	switch e.Dialect {
+	case "postgres", "sqlite":
+		quote = '"'
+	case "mysql":
+		quote = '`'
+	default:
+		return fmt.Errorf("unknown SQL dialect %q", e.Dialect)
+	}
+
+	bw := bufio.NewWriter(w)
+	names := tableNames(tables)
+
+	for i, t := range tables {
+		if len(t.Rows) == 0 {
+			continue
+		}
+
+		var schema Schema
+		if e.Infer {
+			schema = InferSchema(t)
+		} else {
+			schema = Schema{Columns: make([]Column, len(t.Rows[0]))}
+		}
+
+		table := quoteIdent(names[i], quote)
+		columns := uniqueNames(t.Rows[0])
+		quoted := make([]string, len(columns))
+		for j, c := range columns {
+			quoted[j] = quoteIdent(c, quote)
+		}
+
+		fmt.Fprintf(bw, "CREATE TABLE %s (\n", table)
+		for j, c := range quoted {
+			sep := ","
+			if j == len(quoted)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(bw, "  %s %s%s\n", c, e.columnType(schema.Columns[j].Type), sep)
+		}
+		bw.WriteString(");\n")
+
+		prefix := "INSERT INTO " + table + " (" + strings.Join(quoted, ", ") + ") VALUES ("
+		for _, row := range t.Rows[1:] {
+			literals := make([]string, len(row))
+			if e.Infer {
+				values, err := schema.Values(row)
+				if err != nil {
+					return fmt.Errorf("table %s: %w", names[i], err)
+				}
+				for j, v := range values {
+					literals[j] = e.literal(v)
+				}
+			} else {
+				for j, v := range row {
+					literals[j] = e.literal(v)
+				}
+			}
+			bw.WriteString(prefix + strings.Join(literals, ", ") + ");\n")
+		}
+		bw.WriteString("\n")
+	}
+
+	return bw.Flush()
+}
+
+func (e *SQLEncoder) columnType(t ColumnType) string {
+	if e.Dialect == "sqlite" {
+		return sqliteType(t)
+	}
+
+	switch t {
+	case TypeInteger:
+		return "BIGINT"
+	case TypeFloat, TypeCurrency, TypePercent:
+		if e.Dialect == "mysql" {
+			return "DOUBLE"
+		}
+		return "DOUBLE PRECISION"
+	case TypeBoolean:
+		return "BOOLEAN"
+	case TypeTime:
+		if e.Dialect == "mysql" {
+			return "DATETIME"
+		}
+		return "TIMESTAMP"
+	}
+	return "TEXT"
+}
+
+func (e *SQLEncoder) literal(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		switch {
+		case e.Dialect == "sqlite" && v:
+			return "1"
+		case e.Dialect == "sqlite":
+			return "0"
+		case v:
+			return "TRUE"
+		}
+		return "FALSE"
+	case time.Time:
+		return "'" + v.UTC().Format("2006-01-02 15:04:05") + "'"
+	case string:
+		if e.Dialect == "mysql" {
+			v = strings.ReplaceAll(v, `\`, `\\`)
+		}
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return "NULL"
+}
+
+func sqliteType(t ColumnType) string {
+	switch t {
+	case TypeInteger, TypeBoolean:
+		return "INTEGER"
+	case TypeFloat, TypeCurrency, TypePercent:
+		return "REAL"
+	}
+	return "TEXT"
+}
+
+// tableNames returns a unique name for every table, taken from its ID,
+// Name or Index, in that order of preference, followed by its Section.
+func tableNames(tables []Table) []string {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		switch {
+		case t.ID != "":
+			names[i] = t.ID
+		case t.Name != "":
+			names[i] = t.Name
+		default:
+			names[i] = "table_" + strconv.Itoa(t.Index)
+		}
+		if t.Section != "" {
+			names[i] += "_" + t.Section
+		}
+	}
+	return uniqueNames(names)
+}
+
+func quoteIdent(s string, quote rune) string {
+	q := string(quote)
+	return q + strings.ReplaceAll(s, q, q+q) + q
+}
@@ -0,0 +1,140 @@
+package htmltable
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestSQLEncoder_Encode_Dialects(t *testing.T) {
+	tables := []Table{{Index: 1, ID: "files", Rows: [][]string{
+		{"Name", "Size", "Ok", "Date"},
+		{`it's a\b`, "1,024", "yes", "2025-01-02"},
+		{"x", "", "no", "2025-01-03"},
+	}}}
+
+	tests := []struct {
+		dialect string
+		infer   bool
+		want    string
+	}{
+		{"postgres", true, `CREATE TABLE "files" (
+  "Name" TEXT,
+  "Size" BIGINT,
+  "Ok" BOOLEAN,
+  "Date" TIMESTAMP
+);
+INSERT INTO "files" ("Name", "Size", "Ok", "Date") VALUES ('it''s a\b', 1024, TRUE, '2025-01-02 00:00:00');
+INSERT INTO "files" ("Name", "Size", "Ok", "Date") VALUES ('x', NULL, FALSE, '2025-01-03 00:00:00');
+
+`},
+		{"mysql", true, "CREATE TABLE `files` (\n" +
+			"  `Name` TEXT,\n" +
+			"  `Size` BIGINT,\n" +
+			"  `Ok` BOOLEAN,\n" +
+			"  `Date` DATETIME\n" +
+			");\n" +
+			"INSERT INTO `files` (`Name`, `Size`, `Ok`, `Date`) VALUES ('it''s a\\\\b', 1024, TRUE, '2025-01-02 00:00:00');\n" +
+			"INSERT INTO `files` (`Name`, `Size`, `Ok`, `Date`) VALUES ('x', NULL, FALSE, '2025-01-03 00:00:00');\n\n"},
+		{"sqlite", false, `CREATE TABLE "files" (
+  "Name" TEXT,
+  "Size" TEXT,
+  "Ok" TEXT,
+  "Date" TEXT
+);
+INSERT INTO "files" ("Name", "Size", "Ok", "Date") VALUES ('it''s a\b', '1,024', 'yes', '2025-01-02');
+INSERT INTO "files" ("Name", "Size", "Ok", "Date") VALUES ('x', '', 'no', '2025-01-03');
+
+`},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		enc := NewSQLEncoder()
+		enc.Dialect = tt.dialect
+		enc.Infer = tt.infer
+		if err := enc.Encode(&buf, tables); err != nil {
+			t.Fatalf("%s: Encode error: %v", tt.dialect, err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("%s: unexpected output:\n%s\nwant:\n%s", tt.dialect, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestSQLEncoder_Encode_UnknownDialect(t *testing.T) {
+	enc := NewSQLEncoder()
+	enc.Dialect = "oracle"
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, []Table{{Rows: [][]string{{"a"}}}}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestSQLEncoder_Encode_SQLiteScriptLoads(t *testing.T) {
+	tables := []Table{
+		{Index: 1, Rows: [][]string{{"A", "B", "A"}, {"1.5", "true", "x"}, {"2", "false", ""}}},
+		{Index: 2, Name: "other", Rows: [][]string{{"C"}, {"it's"}}},
+	}
+
+	var buf bytes.Buffer
+	enc := &SQLEncoder{Dialect: "sqlite", Infer: true}
+	if err := enc.Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(buf.String()); err != nil {
+		t.Fatalf("script failed: %v\n%s", err, buf.String())
+	}
+
+	var sum float64
+	var trues int
+	if err := db.QueryRow(`SELECT sum("A"), sum("B") FROM table_1`).Scan(&sum, &trues); err != nil {
+		t.Fatalf("query error: %v", err)
+	}
+	if sum != 3.5 || trues != 1 {
+		t.Fatalf("unexpected sums: %v %v", sum, trues)
+	}
+
+	var c string
+	if err := db.QueryRow(`SELECT "C" FROM other`).Scan(&c); err != nil {
+		t.Fatalf("query error: %v", err)
+	}
+	if c != "it's" {
+		t.Fatalf("expected %q, got %q", "it's", c)
+	}
+}
+
+func TestSQLEncoder_Encode_PropagatesWriterError(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a"}, {"b"}}}}
+	if err := NewSQLEncoder().Encode(errWriter{}, tables); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestTableNames(t *testing.T) {
+	got := tableNames([]Table{
+		{Index: 1, ID: "id", Name: "name"},
+		{Index: 2, Name: "name"},
+		{Index: 3},
+		{Index: 4, ID: "id"},
+		{Index: 5, ID: "pop", Section: "Europe"},
+	})
+	assertSliceEqual(t, got, []string{"id", "name", "table_3", "id_2", "pop_Europe"}, "tableNames")
+}
+
+func TestQuoteIdent(t *testing.T) {
+	if got := quoteIdent(`a"b`, '"'); got != `"a""b"` {
+		t.Fatalf("got %s", got)
+	}
+	if got := quoteIdent("a`b", '`'); got != "`a``b`" {
+		t.Fatalf("got %s", got)
+	}
+}
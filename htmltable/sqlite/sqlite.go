@@ -0,0 +1,190 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	htmltable.RegisterEncoder("sqlite", func() htmltable.Encoder { return NewEncoder() })
+}
+
+// Encoder writes every table to a SQLite database, one SQL table per
+// Table, named after its ID, Name or Index.
+// The first row of each table gives the column names, and the column types
+// are inferred from the remaining rows.
+type Encoder struct {
+	// Path is the database file.
+	// If empty, Encode writes a new database to w instead.
+	Path string
+
+	// Append inserts rows into existing tables instead of replacing them.
+	Append bool
+}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+func (e *Encoder) Encode(w io.Writer, tables []htmltable.Table) error {
+	if e.Path != "" {
+		return e.encodeFile(e.Path, tables)
+	}
+
+	f, err := os.CreateTemp("", "html2csv-*.sqlite")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := e.encodeFile(f.Name(), tables); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func (e *Encoder) encodeFile(path string, tables []htmltable.Table) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	names := tableNames(tables)
+	for i, t := range tables {
+		if len(t.Rows) == 0 {
+			continue
+		}
+		if err := e.encodeTable(tx, names[i], t); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+func (e *Encoder) encodeTable(tx *sql.Tx, name string, t htmltable.Table) error {
+	schema := htmltable.InferSchema(t)
+	columns := uniqueNames(t.Rows[0])
+
+	table := quoteIdent(name)
+	defs := make([]string, len(columns))
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quoteIdent(c)
+		defs[i] = quoted[i] + " " + columnType(schema.Columns[i].Type)
+	}
+
+	stmts := []string{"CREATE TABLE IF NOT EXISTS " + table + " (" + strings.Join(defs, ", ") + ")"}
+	if !e.Append {
+		stmts = append([]string{"DROP TABLE IF EXISTS " + table}, stmts...)
+	}
+	for _, s := range stmts {
+		if _, err := tx.Exec(s); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	insert, err := tx.Prepare("INSERT INTO " + table + " (" + strings.Join(quoted, ", ") + ") VALUES (" +
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")")
+	if err != nil {
+		return fmt.Errorf("table %s: %w", name, err)
+	}
+	defer insert.Close()
+
+	for _, row := range t.Rows[1:] {
+		values, err := schema.Values(row)
+		if err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+		for i, v := range values {
+			if v, ok := v.(time.Time); ok {
+				values[i] = v.UTC().Format("2006-01-02 15:04:05")
+			}
+		}
+		if _, err := insert.Exec(values...); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func columnType(t htmltable.ColumnType) string {
+	switch t {
+	case htmltable.TypeInteger, htmltable.TypeBoolean:
+		return "INTEGER"
+	case htmltable.TypeFloat, htmltable.TypeCurrency, htmltable.TypePercent:
+		return "REAL"
+	}
+	return "TEXT"
+}
+
+// tableNames returns a unique name for every table, taken from its ID,
+// Name or Index, in that order of preference, followed by its Section.
+// It matches the names used by htmltable.SQLEncoder.
+func tableNames(tables []htmltable.Table) []string {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		switch {
+		case t.ID != "":
+			names[i] = t.ID
+		case t.Name != "":
+			names[i] = t.Name
+		default:
+			names[i] = "table_" + strconv.Itoa(t.Index)
+		}
+		if t.Section != "" {
+			names[i] += "_" + t.Section
+		}
+	}
+	return uniqueNames(names)
+}
+
+// uniqueNames returns header with empty names replaced by "column_N"
+// (1-based) and duplicates suffixed with "_2", "_3", and so on,
+// as in htmltable.Table.Records.
+func uniqueNames(header []string) []string {
+	names := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+
+	for i, h := range header {
+		name := strings.TrimSpace(h)
+		if name == "" {
+			name = "column_" + strconv.Itoa(i+1)
+		}
+		base := name
+		for n := 2; seen[name]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+		seen[name] = true
+		names[i] = name
+	}
+
+	return names
+}
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ricardobranco777/html2csv/htmltable"
+)
+
+func TestSQLiteEncoder_Encode_CreatesOneTablePerTable(t *testing.T) {
+	tables := []htmltable.Table{
+		{Index: 1, ID: "files", Rows: [][]string{
+			{"Name", "Size", "Enabled", "Modified", "Name"},
+			{"a", "1,024", "yes", "2025-01-02 03:04", "x"},
+			{"b", "", "no", "2025-01-03 00:00", "y"},
+		}},
+		{Index: 2, Name: "quo\"ted", Rows: [][]string{{"A"}, {"1.5"}}},
+		{Index: 3, Rows: [][]string{{"", "B"}, {"p", "q"}}},
+		{Index: 4, ID: "files", Rows: [][]string{{"C"}, {"r"}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "out.sqlite")
+	enc := NewEncoder()
+	enc.Path = path
+	if err := enc.Encode(nil, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	db := openTestDB(t, path)
+
+	var name, enabled, modified, name2 string
+	var size sql.NullInt64
+	rows := queryRows(t, db, `SELECT "Name", "Size", "Enabled", "Modified", "Name_2" FROM files ORDER BY rowid`)
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+	if err := rows.Scan(&name, &size, &enabled, &modified, &name2); err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+	if name != "a" || size.Int64 != 1024 || enabled != "1" || modified != "2025-01-02 03:04:00" || name2 != "x" {
+		t.Fatalf("unexpected row: %q %v %q %q %q", name, size, enabled, modified, name2)
+	}
+	if !rows.Next() {
+		t.Fatal("expected a second row")
+	}
+	if err := rows.Scan(&name, &size, &enabled, &modified, &name2); err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+	if size.Valid {
+		t.Fatalf("expected NULL size, got %v", size)
+	}
+	rows.Close()
+
+	var typ string
+	if err := db.QueryRow(`SELECT typeof("A") FROM "quo""ted"`).Scan(&typ); err != nil {
+		t.Fatalf("query error: %v", err)
+	}
+	if typ != "real" {
+		t.Fatalf("expected real, got %q", typ)
+	}
+
+	var b string
+	if err := db.QueryRow(`SELECT "B" FROM table_3 WHERE column_1 = 'p'`).Scan(&b); err != nil {
+		t.Fatalf("query error: %v", err)
+	}
+	if b != "q" {
+		t.Fatalf("expected q, got %q", b)
+	}
+
+	var c string
+	if err := db.QueryRow(`SELECT "C" FROM files_2`).Scan(&c); err != nil {
+		t.Fatalf("query error: %v", err)
+	}
+}
+
+func TestSQLiteEncoder_Encode_AppendOrReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.sqlite")
+	tables := []htmltable.Table{{Index: 1, Rows: [][]string{{"N"}, {"1"}, {"2"}}}}
+
+	enc := &Encoder{Path: path}
+	for range 2 {
+		if err := enc.Encode(nil, tables); err != nil {
+			t.Fatalf("Encode error: %v", err)
+		}
+	}
+	if n := countRows(t, path, "table_1"); n != 2 {
+		t.Fatalf("expected 2 rows after replace, got %d", n)
+	}
+
+	enc.Append = true
+	if err := enc.Encode(nil, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if n := countRows(t, path, "table_1"); n != 4 {
+		t.Fatalf("expected 4 rows after append, got %d", n)
+	}
+}
+
+func TestSQLiteEncoder_Encode_WritesDatabaseToWriter(t *testing.T) {
+	var buf bytes.Buffer
+	tables := []htmltable.Table{{Index: 1, Rows: [][]string{{"N"}, {"1"}}}}
+	if err := NewEncoder().Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("SQLite format 3\x00")) {
+		t.Fatalf("output is not a SQLite database: %q", buf.Bytes()[:min(buf.Len(), 16)])
+	}
+
+	path := filepath.Join(t.TempDir(), "copy.sqlite")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, path, "table_1"); n != 1 {
+		t.Fatalf("expected 1 row, got %d", n)
+	}
+}
+
+func TestTableNames(t *testing.T) {
+	got := tableNames([]htmltable.Table{
+		{Index: 1, ID: "id", Name: "name"},
+		{Index: 2, Name: "name"},
+		{Index: 3},
+		{Index: 4, ID: "id"},
+		{Index: 5, ID: "pop", Section: "Europe"},
+	})
+	want := []string{"id", "name", "table_3", "id_2", "pop_Europe"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("tableNames = %q, want %q", got, want)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	enc, err := htmltable.NewEncoder("sqlite")
+	if err != nil {
+		t.Fatalf("NewEncoder error: %v", err)
+	}
+	if _, ok := enc.(*Encoder); !ok {
+		t.Fatalf("expected *Encoder, got %T", enc)
+	}
+}
+
+// ---- helpers ----
+
+func openTestDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func queryRows(t *testing.T, db *sql.DB, query string) *sql.Rows {
+	t.Helper()
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatalf("query %q: %v", query, err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+func countRows(t *testing.T, path, table string) int {
+	t.Helper()
+	var n int
+	if err := openTestDB(t, path).QueryRow("SELECT count(*) FROM " + quoteIdent(table)).Scan(&n); err != nil {
+		t.Fatalf("count error: %v", err)
+	}
+	return n
+}
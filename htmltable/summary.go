@@ -0,0 +1,44 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+// Summary describes a table without its full contents.
+type Summary struct {
+	Index   int        `json:"index"`
+	ID      string     `json:"id,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	Caption string     `json:"caption,omitempty"`
+	Section string     `json:"section,omitempty"`
+	Rows    int        `json:"rows"`
+	Columns int        `json:"columns"`
+	Header  []string   `json:"header"`
+	Sample  [][]string `json:"sample"`
+
+	RowHeaders bool `json:"row_headers,omitempty"`
+}
+
+// Summary returns the metadata, dimensions and header row of the table
+// together with up to n rows after the header. A negative n is taken as 0.
+// Rows counts all rows, including the header.
+func (t Table) Summary(n int) Summary {
+	s := Summary{
+		Index:   t.Index,
+		ID:      t.ID,
+		Name:    t.Name,
+		Caption: t.Caption,
+		Section: t.Section,
+		Rows:    len(t.Rows),
+		Header:  []string{},
+		Sample:  [][]string{},
+
+		RowHeaders: t.RowHeaders,
+	}
+	for _, row := range t.Rows {
+		s.Columns = max(s.Columns, len(row))
+	}
+	if len(t.Rows) > 0 {
+		s.Header = t.Rows[0]
+		s.Sample = t.Rows[1:min(len(t.Rows), max(n, 0)+1)]
+	}
+	return s
+}
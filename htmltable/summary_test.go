@@ -0,0 +1,62 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse_Caption(t *testing.T) {
+	html := `
+<table id="t"><caption>
+  Monthly
+  prices <b>2025</b>
+</caption><tr><td>a</td></tr></table>
+<table><tr><td>b</td></tr></table>`
+
+	tables, err := Parse(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(tables))
+	}
+	if tables[0].Caption != "Monthly prices 2025" {
+		t.Fatalf("unexpected caption %q", tables[0].Caption)
+	}
+	if tables[1].Caption != "" {
+		t.Fatalf("expected no caption, got %q", tables[1].Caption)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"a"}}, "rows")
+}
+
+func TestTable_Summary(t *testing.T) {
+	tab := Table{Index: 2, ID: "p", Caption: "Prices", Rows: [][]string{
+		{"Product", "Price"},
+		{"a", "1"},
+		{"b", "2"},
+		{"c", "3"},
+	}}
+
+	s := tab.Summary(2)
+	if s.Index != 2 || s.ID != "p" || s.Caption != "Prices" || s.Rows != 4 || s.Columns != 2 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+	assertSliceEqual(t, s.Header, []string{"Product", "Price"}, "Header")
+	assertRowsEqual(t, s.Sample, [][]string{{"a", "1"}, {"b", "2"}}, "Sample")
+
+	s = tab.Summary(10)
+	if len(s.Sample) != 3 {
+		t.Fatalf("expected 3 sample rows, got %d", len(s.Sample))
+	}
+
+	for _, n := range []int{0, -1} {
+		if s = tab.Summary(n); len(s.Sample) != 0 || len(s.Header) != 2 {
+			t.Fatalf("Summary(%d): unexpected summary: %+v", n, s)
+		}
+	}
+
+	s = Table{Index: 1}.Summary(3)
+	if s.Rows != 0 || s.Columns != 0 || s.Header == nil || s.Sample == nil {
+		t.Fatalf("unexpected summary of empty table: %+v", s)
+	}
+}
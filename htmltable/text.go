@@ -0,0 +1,231 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// TextStyle selects the borders drawn by TextEncoder.
+type TextStyle int
+
+const (
+	// BoxStyle draws borders with Unicode box-drawing characters.
+	BoxStyle TextStyle = iota
+
+	// ASCIIStyle draws borders with +, - and |, and truncates with "...".
+	ASCIIStyle
+
+	// PlainStyle separates columns with two spaces and draws no borders.
+	PlainStyle
+)
+
+type borders struct {
+	top, middle, bottom [3]string // left, junction, right
+	horizontal          string
+	vertical            string
+	ellipsis            string
+}
+
+var textBorders = map[TextStyle]borders{
+	BoxStyle: {
+		top:        [3]string{"┌", "┬", "┐"},
+		middle:     [3]string{"├", "┼", "┤"},
+		bottom:     [3]string{"└", "┴", "┘"},
+		horizontal: "─",
+		vertical:   "│",
+		ellipsis:   "…",
+	},
+	ASCIIStyle: {
+		top:        [3]string{"+", "+", "+"},
+		middle:     [3]string{"+", "+", "+"},
+		bottom:     [3]string{"+", "+", "+"},
+		horizontal: "-",
+		vertical:   "|",
+		ellipsis:   "...",
+	},
+}
+
+// TextEncoder writes tables as aligned text for terminals and plain-text
+// reports. Widths account for East Asian wide characters, and numeric
+// columns are aligned to the right.
+type TextEncoder struct {
+	Style TextStyle
+
+	// MaxWidth limits the width of every column, truncating longer cells.
+	// Zero means no limit.
+	MaxWidth int
+
+	// Header draws a line under the first row of every table.
+	Header bool
+}
+
+func NewTextEncoder() *TextEncoder {
+	return &TextEncoder{Header: true}
+}
+
+func (e *TextEncoder) Encode(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+
+	for i, t := range tables {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		e.encodeTable(bw, t)
+	}
+
+	return bw.Flush()
+}
+
+func (e *TextEncoder) encodeTable(w *bufio.Writer, t Table) {
+	if len(t.Rows) == 0 {
+		return
+	}
+
+	b, boxed := textBorders[e.Style]
+	ellipsis := "…"
+	if boxed {
+		ellipsis = b.ellipsis
+	}
+
+	// Fit cells to the column widths
+	var widths []int
+	rows := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = make([]string, len(row))
+		for c, cell := range row {
+			cell = strings.Map(func(r rune) rune {
+				if r == '\n' || r == '\r' || r == '\t' {
+					return ' '
+				}
+				return r
+			}, cell)
+			if e.MaxWidth > 0 {
+				cell = TruncateWidth(cell, e.MaxWidth, ellipsis)
+			}
+			rows[r][c] = cell
+			if c >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[c] = max(widths[c], StringWidth(cell))
+		}
+	}
+
+	right := make([]bool, len(widths))
+	if e.Header {
+		for c, col := range InferSchema(t).Columns {
+			switch col.Type {
+			case TypeInteger, TypeFloat, TypeCurrency, TypePercent:
+				if c < len(right) {
+					right[c] = true
+				}
+			}
+		}
+	}
+
+	rule := func(edges [3]string) {
+		w.WriteString(edges[0])
+		for c, n := range widths {
+			if c > 0 {
+				w.WriteString(edges[1])
+			}
+			w.WriteString(strings.Repeat(b.horizontal, n+2))
+		}
+		w.WriteString(edges[2] + "\n")
+	}
+
+	if boxed {
+		rule(b.top)
+	}
+	for r, row := range rows {
+		if boxed {
+			w.WriteString(b.vertical)
+		}
+		for c, n := range widths {
+			var cell string
+			if c < len(row) {
+				cell = row[c]
+			}
+			pad := strings.Repeat(" ", n-StringWidth(cell))
+			if right[c] {
+				cell = pad + cell
+			} else {
+				cell += pad
+			}
+
+			switch {
+			case boxed:
+				w.WriteString(" " + cell + " " + b.vertical)
+			case c < len(widths)-1:
+				w.WriteString(cell + "  ")
+			default:
+				w.WriteString(strings.TrimRight(cell, " "))
+			}
+		}
+		w.WriteString("\n")
+
+		if r == 0 && e.Header && len(rows) > 1 {
+			if boxed {
+				rule(b.middle)
+			} else {
+				for c, n := range widths {
+					if c > 0 {
+						w.WriteString("  ")
+					}
+					w.WriteString(strings.Repeat("-", n))
+				}
+				w.WriteString("\n")
+			}
+		}
+	}
+	if boxed {
+		rule(b.bottom)
+	}
+}
+
+// runeWidth returns the number of terminal cells taken by r.
+func runeWidth(r rune) int {
+	if unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of terminal cells taken by s, counting
+// East Asian wide characters as two.
+func StringWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// TruncateWidth shortens s to at most n cells, ending it with ellipsis.
+func TruncateWidth(s string, n int, ellipsis string) string {
+	if StringWidth(s) <= n {
+		return s
+	}
+	room := n - StringWidth(ellipsis)
+	if room < 0 {
+		return strings.Repeat(".", n)
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if room -= runeWidth(r); room < 0 {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + ellipsis
+}
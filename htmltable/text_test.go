@@ -0,0 +1,95 @@
+package htmltable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTextEncoder_Encode_Styles(t *testing.T) {
+	tables := []Table{
+		{Rows: [][]string{{"Name", "Size"}, {"a", "1,024"}, {"日本", "7"}}},
+		{Rows: [][]string{{"x"}}},
+	}
+
+	tests := []struct {
+		style TextStyle
+		want  string
+	}{
+		{BoxStyle, "" +
+			"┌──────┬───────┐\n" +
+			"│ Name │  Size │\n" +
+			"├──────┼───────┤\n" +
+			"│ a    │ 1,024 │\n" +
+			"│ 日本 │     7 │\n" +
+			"└──────┴───────┘\n" +
+			"\n" +
+			"┌───┐\n" +
+			"│ x │\n" +
+			"└───┘\n"},
+		{ASCIIStyle, "" +
+			"+------+-------+\n" +
+			"| Name |  Size |\n" +
+			"+------+-------+\n" +
+			"| a    | 1,024 |\n" +
+			"| 日本 |     7 |\n" +
+			"+------+-------+\n" +
+			"\n" +
+			"+---+\n" +
+			"| x |\n" +
+			"+---+\n"},
+		{PlainStyle, "" +
+			"Name   Size\n" +
+			"----  -----\n" +
+			"a     1,024\n" +
+			"日本      7\n" +
+			"\n" +
+			"x\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		enc := NewTextEncoder()
+		enc.Style = tt.style
+		if err := enc.Encode(&buf, tables); err != nil {
+			t.Fatalf("Encode error: %v", err)
+		}
+		if buf.String() != tt.want {
+			t.Fatalf("style %d: got\n%s\nwant\n%s", tt.style, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestTextEncoder_Encode_MaxWidth(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"Description"}, {"multi\nline"}, {"日本語テキスト"}}}}
+
+	var buf bytes.Buffer
+	enc := &TextEncoder{Style: ASCIIStyle, MaxWidth: 7}
+	if err := enc.Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	want := "" +
+		"+---------+\n" +
+		"| Desc... |\n" +
+		"| mult... |\n" +
+		"| 日本... |\n" +
+		"+---------+\n"
+	if buf.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := map[string]int{"abc": 3, "日本": 4, "ｱ": 1, "ｈ": 2, "é": 1, "": 0}
+	for s, want := range tests {
+		if got := StringWidth(s); got != want {
+			t.Fatalf("StringWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTextEncoder_Encode_PropagatesWriterError(t *testing.T) {
+	tables := []Table{{Rows: [][]string{{"a"}}}}
+	if err := NewTextEncoder().Encode(errWriter{}, tables); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
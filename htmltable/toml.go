@@ -0,0 +1,93 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TOMLEncoder writes tables as a TOML array of tables named "tables",
+// each holding its metadata and a "rows" array of tables keyed by column
+// name. Column names are made unique as in Records, and all values are
+// strings.
+type TOMLEncoder struct{}
+
+func NewTOMLEncoder() *TOMLEncoder {
+	return &TOMLEncoder{}
+}
+
+func (e *TOMLEncoder) Encode(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+
+	for i, t := range tables {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		bw.WriteString("[[tables]]\n")
+		bw.WriteString("index = " + strconv.Itoa(t.Index) + "\n")
+		for _, kv := range tableMetadata(t) {
+			bw.WriteString(kv[0] + " = " + tomlString(kv[1]) + "\n")
+		}
+		if len(t.Rows) < 2 {
+			bw.WriteString("rows = []\n")
+			continue
+		}
+
+		keys := uniqueNames(t.Rows[0])
+		for _, row := range t.Rows[1:] {
+			bw.WriteString("\n[[tables.rows]]\n")
+			for i, k := range keys {
+				var v string
+				if i < len(row) {
+					v = row[i]
+				}
+				bw.WriteString(tomlKey(k) + " = " + tomlString(v) + "\n")
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// tomlKey returns k as a bare key if possible, and as a quoted key otherwise.
+func tomlKey(k string) string {
+	if k != "" && strings.Trim(k, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-") == "" {
+		return k
+	}
+	return tomlString(k)
+}
+
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				b.WriteString(`\u` + fmt.Sprintf("%04X", r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
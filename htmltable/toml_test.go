@@ -0,0 +1,60 @@
+package htmltable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTOMLEncoder_Encode(t *testing.T) {
+	tables := []Table{
+		{Index: 1, Name: "prices", Rows: [][]string{
+			{"Name", "Unit price", "Name"},
+			{"apple", "1.5", "x"},
+			{"quo\"te", "", "a\\b\n"},
+		}},
+		{Index: 2, Section: "Europe", Rows: [][]string{{"A"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewTOMLEncoder().Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	want := `[[tables]]
+index = 1
+name = "prices"
+
+[[tables.rows]]
+Name = "apple"
+"Unit price" = "1.5"
+Name_2 = "x"
+
+[[tables.rows]]
+Name = "quo\"te"
+"Unit price" = ""
+Name_2 = "a\\b\n"
+
+[[tables]]
+index = 2
+section = "Europe"
+rows = []
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTOMLKey(t *testing.T) {
+	tests := map[string]string{
+		"bare_key-1": "bare_key-1",
+		"":           `""`,
+		"a.b":        `"a.b"`,
+		"Café":       `"Café"`,
+		"\x7f":       `"\u007F"`,
+	}
+	for in, want := range tests {
+		if got := tomlKey(in); got != want {
+			t.Errorf("tomlKey(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ValueRule selects where a cell value is taken from.
+// An empty Attr selects the text content of the cell.
+// A non-empty Tag restricts the lookup to elements with that tag name.
+type ValueRule struct {
+	Tag  string
+	Attr string
+}
+
+// ParseValueRules parses a comma-separated list of rules such as
+// "data-sort-value,title,time@datetime,img@alt,text".
+func ParseValueRules(s string) ([]ValueRule, error) {
+	var rules []ValueRule
+
+	for part := range strings.SplitSeq(s, ",") {
+		p := strings.ToLower(strings.TrimSpace(part))
+		if p == "" {
+			continue
+		}
+		if p == "text" {
+			rules = append(rules, ValueRule{})
+			continue
+		}
+
+		tag, attr, hasTag := strings.Cut(p, "@")
+		if !hasTag {
+			tag, attr = "", p
+		}
+		if attr == "" || hasTag && tag == "" {
+			return nil, fmt.Errorf("invalid value rule %q", strings.TrimSpace(part))
+		}
+		rules = append(rules, ValueRule{Tag: tag, Attr: attr})
+	}
+
+	return rules, nil
+}
+
+// cellValue returns the first non-blank value matched by rules,
+// falling back to the text content of the cell.
+// Leading and trailing whitespace is removed unless keepSpace is set.
+func cellValue(cell *html.Node, rules []ValueRule, keepSpace bool) string {
+	v := textContent(cell)
+	for _, r := range rules {
+		if s := r.value(cell); strings.TrimSpace(s) != "" {
+			v = s
+			break
+		}
+	}
+	if keepSpace {
+		return v
+	}
+	return strings.TrimSpace(v)
+}
+
+func (r ValueRule) value(cell *html.Node) string {
+	if r.Attr == "" {
+		return textContent(cell)
+	}
+
+	var found string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if found != "" {
+			return
+		}
+		if n.Type == html.ElementNode && (r.Tag == "" || n.Data == r.Tag) {
+			if v, ok := attrValue(n, r.Attr); ok && strings.TrimSpace(v) != "" {
+				found = v
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	walk(cell)
+	return found
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
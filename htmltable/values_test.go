@@ -0,0 +1,96 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseValueRules_MixedRules(t *testing.T) {
+	rules, err := ParseValueRules(" data-sort-value, TIME@datetime,,img@alt , text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ValueRule{
+		{Attr: "data-sort-value"},
+		{Tag: "time", Attr: "datetime"},
+		{Tag: "img", Attr: "alt"},
+		{},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("expected %d rules, got %d: %+v", len(want), len(rules), rules)
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Fatalf("rule[%d]: got %+v want %+v", i, rules[i], want[i])
+		}
+	}
+}
+
+func TestParseValueRules_Invalid(t *testing.T) {
+	for _, in := range []string{"img@", "@alt", "title,@"} {
+		if _, err := ParseValueRules(in); err == nil {
+			t.Fatalf("expected error for %q, got nil", in)
+		}
+	}
+}
+
+func TestParser_Values_PrefersAttributesThenText(t *testing.T) {
+	src := `
+<table>
+  <tr><th>Name</th><th>Size</th><th>Date</th><th>Flag</th><th>Qty</th></tr>
+  <tr>
+    <td title="Full name">Short</td>
+    <td data-sort-value="1024">1K</td>
+    <td><time datetime="2025-01-02T03:04:05Z">yesterday</time></td>
+    <td><img src="x.png" alt="yes"></td>
+    <td><input value="7"></td>
+  </tr>
+  <tr>
+    <td>plain</td>
+    <td data-sort-value="">2K</td>
+    <td>unknown</td>
+    <td><img src="y.png"></td>
+    <td>3</td>
+  </tr>
+</table>`
+
+	rules, err := ParseValueRules("data-sort-value,title,time@datetime,img@alt,input@value,text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := Parser{Values: rules}
+
+	tables, err := p.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+
+	want := [][]string{
+		{"Name", "Size", "Date", "Flag", "Qty"},
+		{"Full name", "1024", "2025-01-02T03:04:05Z", "yes", "7"},
+		{"plain", "2K", "unknown", "", "3"},
+	}
+	assertRowsEqual(t, tables[0].Rows, want, "Rows")
+}
+
+func TestParser_Values_TagRestrictsLookup(t *testing.T) {
+	src := `<table><tr><td title="cell"><abbr title="inner">x</abbr></td></tr></table>`
+
+	p := Parser{Values: []ValueRule{{Tag: "abbr", Attr: "title"}}}
+	tables, err := p.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"inner"}}, "Rows")
+
+	p = Parser{Values: []ValueRule{{Attr: "title"}}}
+	tables, err = p.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	assertRowsEqual(t, tables[0].Rows, [][]string{{"cell"}}, "Rows")
+}
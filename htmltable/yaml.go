@@ -0,0 +1,105 @@
+/* SPDX-License-Identifier: BSD-2-Clause */
+
+package htmltable
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// YAMLEncoder writes tables as a YAML list with one mapping per table,
+// holding its metadata and its rows as mappings keyed by column name.
+// Column names are made unique as in Records, and all values are strings.
+type YAMLEncoder struct{}
+
+func NewYAMLEncoder() *YAMLEncoder {
+	return &YAMLEncoder{}
+}
+
+func (e *YAMLEncoder) Encode(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+
+	if len(tables) == 0 {
+		bw.WriteString("[]\n")
+	}
+	for _, t := range tables {
+		fmt.Fprintf(bw, "- index: %d\n", t.Index)
+		for _, kv := range tableMetadata(t) {
+			fmt.Fprintf(bw, "  %s: %s\n", kv[0], yamlString(kv[1]))
+		}
+
+		if len(t.Rows) < 2 {
+			bw.WriteString("  rows: []\n")
+			continue
+		}
+		bw.WriteString("  rows:\n")
+		keys := uniqueNames(t.Rows[0])
+		for _, row := range t.Rows[1:] {
+			for i, k := range keys {
+				prefix := "      "
+				if i == 0 {
+					prefix = "    - "
+				}
+				var v string
+				if i < len(row) {
+					v = row[i]
+				}
+				fmt.Fprintf(bw, "%s%s: %s\n", prefix, yamlString(k), yamlString(v))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// tableMetadata returns the non-empty ID, Name, Caption and Section of t.
+func tableMetadata(t Table) [][2]string {
+	var out [][2]string
+	for _, kv := range [][2]string{{"id", t.ID}, {"name", t.Name}, {"caption", t.Caption}, {"section", t.Section}} {
+		if kv[1] != "" {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+var (
+	yamlPlain    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9 _./()-]*$`)
+	yamlReserved = regexp.MustCompile(`^(?i:y|n|yes|no|true|false|on|off|null|nan|inf)$`)
+)
+
+// yamlString returns s as a plain scalar if it cannot be mistaken for
+// another type, and as a double-quoted scalar otherwise.
+func yamlString(s string) string {
+	if yamlPlain.MatchString(s) && !strings.HasSuffix(s, " ") && !yamlReserved.MatchString(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == 0x85 || r == 0x2028 || r == 0x2029 || r == 0xfeff {
+				b.WriteString(`\u` + fmt.Sprintf("%04x", r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
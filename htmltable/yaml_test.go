@@ -0,0 +1,60 @@
+package htmltable
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestYAMLEncoder_Encode(t *testing.T) {
+	tables := []Table{
+		{Index: 1, ID: "prices", Caption: "Monthly prices", Rows: [][]string{
+			{"Name", "Price", "Name"},
+			{"apple", "1.5", "yes"},
+			{"Café: \"x\"", "", "a\nb"},
+		}},
+		{Index: 2, Section: "Europe", Rows: [][]string{{"A"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewYAMLEncoder().Encode(&buf, tables); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+
+	want := `- index: 1
+  id: prices
+  caption: Monthly prices
+  rows:
+    - Name: apple
+      Price: "1.5"
+      Name_2: "yes"
+    - Name: "Café: \"x\""
+      Price: ""
+      Name_2: "a\nb"
+- index: 2
+  section: Europe
+  rows: []
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := map[string]string{
+		"plain text": "plain text",
+		"":           `""`,
+		"No":         `"No"`,
+		"null":       `"null"`,
+		"42":         `"42"`,
+		"- item":     `"- item"`,
+		"a: b":       `"a: b"`,
+		"trailing ":  `"trailing "`,
+		"tab\there":  `"tab\there"`,
+		"\x01":       `"\u0001"`,
+	}
+	for in, want := range tests {
+		if got := yamlString(in); got != want {
+			t.Errorf("yamlString(%q) = %s, want %s", in, got, want)
+		}
+	}
+}